@@ -0,0 +1,59 @@
+package mako_time_converter
+
+import (
+	"context"
+	"time"
+)
+
+// ConversionResult is the outcome of converting a single timestamp in a batch (see GasTagConverter.ConvertAll and GasTagConverter.ConvertStream)
+type ConversionResult struct {
+	// Input is the timestamp before the conversion
+	Input time.Time
+	// Output is the converted timestamp (zero if Err is not nil)
+	Output time.Time
+	// Err is the error that occurred while converting Input (if any)
+	Err error
+}
+
+func (l locationBasedGasTagConverter) ConvertAll(timestamps []time.Time, configuration DateTimeConversionConfiguration) ([]ConversionResult, error) {
+	plan, err := l.Compile(configuration)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]ConversionResult, len(timestamps))
+	for index, timestamp := range timestamps {
+		output, err := plan.Apply(timestamp)
+		results[index] = ConversionResult{Input: timestamp, Output: output, Err: err}
+	}
+	return results, nil
+}
+
+func (l locationBasedGasTagConverter) ConvertStream(ctx context.Context, in <-chan time.Time, configuration DateTimeConversionConfiguration) (<-chan ConversionResult, error) {
+	plan, err := l.Compile(configuration)
+	if err != nil {
+		return nil, err
+	}
+	out := make(chan ConversionResult)
+	go func() {
+		defer close(out)
+		for {
+			var timestamp time.Time
+			var ok bool
+			select {
+			case <-ctx.Done():
+				return
+			case timestamp, ok = <-in:
+				if !ok {
+					return
+				}
+			}
+			output, err := plan.Apply(timestamp)
+			select {
+			case <-ctx.Done():
+				return
+			case out <- ConversionResult{Input: timestamp, Output: output, Err: err}:
+			}
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,160 @@
+package mako_time_converter_test
+
+import (
+	"context"
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/mako_time_converter"
+	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
+	"testing"
+	"time"
+)
+
+var inclusiveToExclusiveGasConversion = mako_time_converter.DateTimeConversionConfiguration{
+	Source: mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(false), IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.INCLUSIVE)},
+	Target: mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(true), IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.EXCLUSIVE)},
+}
+
+func (s *Suite) Test_ConvertAll() {
+	inputs := []time.Time{
+		time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC),
+		time.Date(2023, 5, 31, 22, 0, 0, 0, time.UTC),
+		time.Date(2023, 5, 31, 12, 0, 0, 0, time.UTC), // not midnight, so there's no gas tag shift
+	}
+	expected := []time.Time{
+		time.Date(2023, 1, 2, 5, 0, 0, 0, time.UTC),
+		time.Date(2023, 6, 2, 4, 0, 0, 0, time.UTC),
+		time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	converter := getBerlinConverter()
+	results, err := converter.ConvertAll(inputs, inclusiveToExclusiveGasConversion)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), len(results), is.EqualTo(len(inputs)))
+	for index, result := range results {
+		then.AssertThat(s.T(), result.Err, is.Nil())
+		then.AssertThat(s.T(), result.Input, is.EqualTo(inputs[index]))
+		then.AssertThat(s.T(), result.Output, is.EqualTo(expected[index]))
+		single, err := converter.Convert(inputs[index], inclusiveToExclusiveGasConversion)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), result.Output, is.EqualTo(single))
+	}
+}
+
+func (s *Suite) Test_ConvertAll_Rejects_Invalid_Configuration() {
+	invalidConfig := mako_time_converter.DateTimeConversionConfiguration{
+		Source: mako_time_converter.DateTimeConfiguration{IsGas: true},
+		Target: mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(true)},
+	}
+	converter := getBerlinConverter()
+	results, err := converter.ConvertAll([]time.Time{time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC)}, invalidConfig)
+	then.AssertThat(s.T(), err, is.Not(is.Nil()))
+	then.AssertThat(s.T(), results == nil, is.True())
+}
+
+func (s *Suite) Test_ConvertStream() {
+	inputs := []time.Time{
+		time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC),
+		time.Date(2023, 5, 31, 22, 0, 0, 0, time.UTC),
+	}
+	in := make(chan time.Time)
+	go func() {
+		defer close(in)
+		for _, input := range inputs {
+			in <- input
+		}
+	}()
+	converter := getBerlinConverter()
+	out, err := converter.ConvertStream(context.Background(), in, inclusiveToExclusiveGasConversion)
+	then.AssertThat(s.T(), err, is.Nil())
+	var results []mako_time_converter.ConversionResult
+	for result := range out {
+		results = append(results, result)
+	}
+	then.AssertThat(s.T(), len(results), is.EqualTo(len(inputs)))
+	for index, result := range results {
+		then.AssertThat(s.T(), result.Err, is.Nil())
+		then.AssertThat(s.T(), result.Input, is.EqualTo(inputs[index]))
+		expected, err := converter.Convert(inputs[index], inclusiveToExclusiveGasConversion)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), result.Output, is.EqualTo(expected))
+	}
+}
+
+func (s *Suite) Test_ConvertStream_Rejects_Invalid_Configuration() {
+	invalidConfig := mako_time_converter.DateTimeConversionConfiguration{
+		Source: mako_time_converter.DateTimeConfiguration{IsEndDate: true},
+		Target: mako_time_converter.DateTimeConfiguration{IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.EXCLUSIVE)},
+	}
+	converter := getBerlinConverter()
+	out, err := converter.ConvertStream(context.Background(), make(chan time.Time), invalidConfig)
+	then.AssertThat(s.T(), err, is.Not(is.Nil()))
+	then.AssertThat(s.T(), out == nil, is.True())
+}
+
+func (s *Suite) Test_ConvertStream_Stops_If_Context_Is_Done() {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan time.Time)
+	go func() {
+		// the in channel is never closed; without the context the stream would never end
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case in <- time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC):
+			}
+		}
+	}()
+	out, err := getBerlinConverter().ConvertStream(ctx, in, inclusiveToExclusiveGasConversion)
+	then.AssertThat(s.T(), err, is.Nil())
+	result := <-out
+	then.AssertThat(s.T(), result.Err, is.Nil())
+	// stop reading after the first result, the stream has to be closed anyway
+	cancel()
+	for range out {
+	}
+}
+
+// benchmarkTimestamps returns n German midnights, starting at 2023-01-01
+func benchmarkTimestamps(n int) []time.Time {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	timestamps := make([]time.Time, n)
+	for index := range timestamps {
+		timestamps[index] = time.Date(2023, 1, 1+index%3650, 0, 0, 0, 0, berlin).UTC()
+	}
+	return timestamps
+}
+
+func BenchmarkConvert(b *testing.B) {
+	converter := getBerlinConverter()
+	timestamps := benchmarkTimestamps(b.N)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for index := 0; index < b.N; index++ {
+		_, _ = converter.Convert(timestamps[index], inclusiveToExclusiveGasConversion)
+	}
+}
+
+func BenchmarkConvertAll(b *testing.B) {
+	converter := getBerlinConverter()
+	timestamps := benchmarkTimestamps(b.N)
+	b.ReportAllocs()
+	b.ResetTimer()
+	_, _ = converter.ConvertAll(timestamps, inclusiveToExclusiveGasConversion)
+}
+
+func BenchmarkConvertStream(b *testing.B) {
+	converter := getBerlinConverter()
+	timestamps := benchmarkTimestamps(b.N)
+	in := make(chan time.Time, 1024)
+	b.ReportAllocs()
+	b.ResetTimer()
+	go func() {
+		defer close(in)
+		for _, timestamp := range timestamps {
+			in <- timestamp
+		}
+	}()
+	out, _ := converter.ConvertStream(context.Background(), in, inclusiveToExclusiveGasConversion)
+	for range out {
+	}
+}
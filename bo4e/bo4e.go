@@ -0,0 +1,158 @@
+// Package bo4e converts the dates of BO4E (Business Objects for Energy, https://www.bo4e.de/) objects with the semantics of a mako_time_converter.DateTimeConversionConfiguration.
+// It contains local structs in the shape of the BO4E objects Zeitraum, Vertrag and Energiemenge (reduced to the fields that are relevant for dates), so that it has no dependency on a specific BO4E implementation.
+package bo4e
+
+import (
+	"fmt"
+	"github.com/hochfrequenz/mako_time_converter"
+	"github.com/hochfrequenz/mako_time_converter/sparte"
+	"time"
+)
+
+// ErrUnknownSparte is returned if an object has no (or an unknown) Sparte; it is the same as mako_time_converter.ErrUnknownSparte
+var ErrUnknownSparte = mako_time_converter.ErrUnknownSparte
+
+// Zeitraum is a period of time; either Startdatum/Enddatum or Startzeitpunkt/Endzeitpunkt are usually set
+type Zeitraum struct {
+	Startdatum     *time.Time `json:"startdatum,omitempty"`
+	Enddatum       *time.Time `json:"enddatum,omitempty"`
+	Startzeitpunkt *time.Time `json:"startzeitpunkt,omitempty"`
+	Endzeitpunkt   *time.Time `json:"endzeitpunkt,omitempty"`
+}
+
+// Vertrag is a contract
+type Vertrag struct {
+	Vertragsnummer string        `json:"vertragsnummer,omitempty"`
+	Sparte         sparte.Sparte `json:"sparte"`
+	Vertragsbeginn *time.Time    `json:"vertragsbeginn,omitempty"`
+	Vertragsende   *time.Time    `json:"vertragsende,omitempty"`
+}
+
+// Verbrauch is a consumption value in a period
+type Verbrauch struct {
+	Startdatum   *time.Time `json:"startdatum,omitempty"`
+	Enddatum     *time.Time `json:"enddatum,omitempty"`
+	Wert         float64    `json:"wert"`
+	Einheit      string     `json:"einheit,omitempty"`
+	Obiskennzahl string     `json:"obiskennzahl,omitempty"`
+}
+
+// Energiemenge contains the consumption values of a Marktlokation or Messlokation
+type Energiemenge struct {
+	LokationsId      string      `json:"lokationsId,omitempty"`
+	Lokationstyp     string      `json:"lokationstyp,omitempty"`
+	Energieverbrauch []Verbrauch `json:"energieverbrauch,omitempty"`
+}
+
+// configurationsFor returns the start and end date configuration for the given Sparte. The end date configuration is the given configuration; IsGas is set according to the Sparte (IsGasTagAware is removed for all Sparten but Gas). The start date configuration has the same Sparte but no end date.
+func configurationsFor(configuration mako_time_converter.DateTimeConversionConfiguration, s sparte.Sparte) (start, end mako_time_converter.DateTimeConversionConfiguration, err error) {
+	if !s.IsValid() {
+		return start, end, fmt.Errorf("%w: %d", ErrUnknownSparte, s)
+	}
+	isGas := s == sparte.GAS
+	end = configuration
+	for _, side := range []*mako_time_converter.DateTimeConfiguration{&end.Source, &end.Target} {
+		side.IsGas = isGas
+		if !isGas {
+			side.IsGasTagAware = nil
+		}
+	}
+	start = end
+	for _, side := range []*mako_time_converter.DateTimeConfiguration{&start.Source, &start.Target} {
+		side.IsEndDate = false
+		side.EndDateTimeKind = nil
+	}
+	return start, end, nil
+}
+
+// fieldConversion collects the converted values of fields, so that the fields are only changed if all of them could be converted
+type fieldConversion struct {
+	converter mako_time_converter.GasTagConverter
+	fields    []*time.Time
+	results   []time.Time
+}
+
+// convert converts the timestamp to which field points without changing it; nil fields are ignored
+func (fc *fieldConversion) convert(field *time.Time, configuration mako_time_converter.DateTimeConversionConfiguration, name string) error {
+	if field == nil {
+		return nil
+	}
+	result, err := fc.converter.Convert(*field, configuration)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	fc.fields = append(fc.fields, field)
+	fc.results = append(fc.results, result)
+	return nil
+}
+
+// apply writes the converted values to the fields
+func (fc *fieldConversion) apply() {
+	for index, field := range fc.fields {
+		*field = fc.results[index]
+	}
+}
+
+// ConvertZeitraum converts the dates of the zeitraum in place: Startdatum and Startzeitpunkt as start dates, Enddatum and Endzeitpunkt with the end date rules of the configuration. The Sparte selects Gas or Strom handling (see ConvertVertrag).
+// If an error is returned, the zeitraum is unchanged.
+func ConvertZeitraum(converter mako_time_converter.GasTagConverter, zeitraum *Zeitraum, s sparte.Sparte, configuration mako_time_converter.DateTimeConversionConfiguration) error {
+	start, end, err := configurationsFor(configuration, s)
+	if err != nil {
+		return err
+	}
+	conversion := fieldConversion{converter: converter}
+	if err = conversion.convert(zeitraum.Startdatum, start, "startdatum"); err != nil {
+		return err
+	}
+	if err = conversion.convert(zeitraum.Enddatum, end, "enddatum"); err != nil {
+		return err
+	}
+	if err = conversion.convert(zeitraum.Startzeitpunkt, start, "startzeitpunkt"); err != nil {
+		return err
+	}
+	if err = conversion.convert(zeitraum.Endzeitpunkt, end, "endzeitpunkt"); err != nil {
+		return err
+	}
+	conversion.apply()
+	return nil
+}
+
+// ConvertVertrag converts Vertragsbeginn (start date) and Vertragsende (end date) of the vertrag in place.
+// The configuration describes the end dates; IsGas is derived from the Sparte of the vertrag, so the same configuration can be used for Gas and Strom contracts (IsGasTagAware is only evaluated for Gas). All Sparten except Gas are handled like Strom.
+// If an error is returned, the vertrag is unchanged.
+func ConvertVertrag(converter mako_time_converter.GasTagConverter, vertrag *Vertrag, configuration mako_time_converter.DateTimeConversionConfiguration) error {
+	start, end, err := configurationsFor(configuration, vertrag.Sparte)
+	if err != nil {
+		return err
+	}
+	conversion := fieldConversion{converter: converter}
+	if err = conversion.convert(vertrag.Vertragsbeginn, start, "vertragsbeginn"); err != nil {
+		return err
+	}
+	if err = conversion.convert(vertrag.Vertragsende, end, "vertragsende"); err != nil {
+		return err
+	}
+	conversion.apply()
+	return nil
+}
+
+// ConvertEnergiemenge converts Startdatum and Enddatum of all Energieverbrauch entries of the energiemenge in place. The Sparte selects Gas or Strom handling (see ConvertVertrag).
+// If an error is returned, the energiemenge is unchanged.
+func ConvertEnergiemenge(converter mako_time_converter.GasTagConverter, energiemenge *Energiemenge, s sparte.Sparte, configuration mako_time_converter.DateTimeConversionConfiguration) error {
+	start, end, err := configurationsFor(configuration, s)
+	if err != nil {
+		return err
+	}
+	conversion := fieldConversion{converter: converter}
+	for index := range energiemenge.Energieverbrauch {
+		verbrauch := &energiemenge.Energieverbrauch[index]
+		if err = conversion.convert(verbrauch.Startdatum, start, fmt.Sprintf("energieverbrauch[%d].startdatum", index)); err != nil {
+			return err
+		}
+		if err = conversion.convert(verbrauch.Enddatum, end, fmt.Sprintf("energieverbrauch[%d].enddatum", index)); err != nil {
+			return err
+		}
+	}
+	conversion.apply()
+	return nil
+}
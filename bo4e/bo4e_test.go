@@ -0,0 +1,130 @@
+package bo4e_test
+
+import (
+	"errors"
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/mako_time_converter"
+	"github.com/hochfrequenz/mako_time_converter/bo4e"
+	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
+	"github.com/hochfrequenz/mako_time_converter/sparte"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type Suite struct {
+	suite.Suite
+	converter mako_time_converter.GasTagConverter
+	// toMaKo converts inclusive, gas day unaware end dates to MaKo end dates
+	toMaKo mako_time_converter.DateTimeConversionConfiguration
+}
+
+// SetupSuite sets up the tests
+func (s *Suite) SetupSuite() {
+	s.converter = mako_time_converter.NewGasTagConverter("Europe/Berlin")
+	s.toMaKo = mako_time_converter.DateTimeConversionConfiguration{
+		Source: mako_time_converter.DateTimeConfiguration{IsGasTagAware: pointer(false), IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.INCLUSIVE)},
+		Target: mako_time_converter.DateTimeConfiguration{IsGasTagAware: pointer(true), IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.EXCLUSIVE)},
+	}
+}
+
+func TestInit(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+func pointer[T any](b T) *T {
+	return &b
+}
+
+func (s *Suite) Test_ConvertVertrag_Selects_Gas_Or_Strom_By_Sparte() {
+	january := func(s sparte.Sparte) bo4e.Vertrag {
+		return bo4e.Vertrag{
+			Sparte:         s,
+			Vertragsbeginn: pointer(time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC)),
+			Vertragsende:   pointer(time.Date(2023, 1, 30, 23, 0, 0, 0, time.UTC)), // 2023-01-31 inclusive
+		}
+	}
+	gasVertrag := january(sparte.GAS)
+	err := bo4e.ConvertVertrag(s.converter, &gasVertrag, s.toMaKo)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), *gasVertrag.Vertragsbeginn, is.EqualTo(time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), *gasVertrag.Vertragsende, is.EqualTo(time.Date(2023, 2, 1, 5, 0, 0, 0, time.UTC)))
+
+	for _, nonGas := range []sparte.Sparte{sparte.STROM, sparte.WASSER, sparte.FERNWAERME} {
+		vertrag := january(nonGas)
+		err = bo4e.ConvertVertrag(s.converter, &vertrag, s.toMaKo)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), *vertrag.Vertragsbeginn, is.EqualTo(time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC)))
+		then.AssertThat(s.T(), *vertrag.Vertragsende, is.EqualTo(time.Date(2023, 1, 31, 23, 0, 0, 0, time.UTC)))
+	}
+
+	vertrag := bo4e.Vertrag{Vertragsbeginn: pointer(time.Now())}
+	err = bo4e.ConvertVertrag(s.converter, &vertrag, s.toMaKo)
+	then.AssertThat(s.T(), errors.Is(err, bo4e.ErrUnknownSparte), is.True())
+}
+
+func (s *Suite) Test_ConvertZeitraum() {
+	zeitraum := bo4e.Zeitraum{
+		Startdatum:   pointer(time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC)),
+		Enddatum:     pointer(time.Date(2023, 1, 30, 23, 0, 0, 0, time.UTC)),
+		Endzeitpunkt: pointer(time.Date(2023, 1, 31, 12, 0, 0, 0, time.UTC)), // not a day boundary, only shifted by one day
+	}
+	err := bo4e.ConvertZeitraum(s.converter, &zeitraum, sparte.GAS, s.toMaKo)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), *zeitraum.Startdatum, is.EqualTo(time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), *zeitraum.Enddatum, is.EqualTo(time.Date(2023, 2, 1, 5, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), zeitraum.Startzeitpunkt == nil, is.True())
+	then.AssertThat(s.T(), *zeitraum.Endzeitpunkt, is.EqualTo(time.Date(2023, 2, 1, 12, 0, 0, 0, time.UTC)))
+
+	err = bo4e.ConvertZeitraum(s.converter, &zeitraum, sparte.Sparte(42), s.toMaKo)
+	then.AssertThat(s.T(), errors.Is(err, bo4e.ErrUnknownSparte), is.True())
+}
+
+func (s *Suite) Test_ConvertEnergiemenge() {
+	energiemenge := bo4e.Energiemenge{
+		LokationsId: "51238696781",
+		Energieverbrauch: []bo4e.Verbrauch{
+			{Startdatum: pointer(time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC)), Enddatum: pointer(time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC)), Wert: 1},
+			{Startdatum: pointer(time.Date(2023, 1, 1, 23, 0, 0, 0, time.UTC)), Enddatum: pointer(time.Date(2023, 1, 1, 23, 0, 0, 0, time.UTC)), Wert: 2},
+		},
+	}
+	err := bo4e.ConvertEnergiemenge(s.converter, &energiemenge, sparte.GAS, s.toMaKo)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), *energiemenge.Energieverbrauch[0].Startdatum, is.EqualTo(time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), *energiemenge.Energieverbrauch[0].Enddatum, is.EqualTo(time.Date(2023, 1, 2, 5, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), *energiemenge.Energieverbrauch[1].Startdatum, is.EqualTo(time.Date(2023, 1, 2, 5, 0, 0, 0, time.UTC)))
+
+	invalidConfiguration := s.toMaKo
+	invalidConfiguration.Source.EndDateTimeKind = nil
+	err = bo4e.ConvertEnergiemenge(s.converter, &energiemenge, sparte.GAS, invalidConfiguration)
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+	then.AssertThat(s.T(), err.Error(), is.StringContaining("energieverbrauch[0].enddatum"))
+}
+
+func (s *Suite) Test_Conversion_Errors_Leave_Objects_Unchanged() {
+	// the start dates can be converted, but the end dates can not
+	invalidEnd := s.toMaKo
+	invalidEnd.Source.StripTime = true
+	invalidEnd.Source.EndDateTimeKind = pointer(enddatetimekind.INCLUSIVE_LAST_SECOND)
+	start := time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 1, 30, 23, 0, 0, 0, time.UTC)
+
+	zeitraum := bo4e.Zeitraum{Startdatum: pointer(start), Enddatum: pointer(end)}
+	err := bo4e.ConvertZeitraum(s.converter, &zeitraum, sparte.GAS, invalidEnd)
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+	then.AssertThat(s.T(), *zeitraum.Startdatum, is.EqualTo(start))
+	then.AssertThat(s.T(), *zeitraum.Enddatum, is.EqualTo(end))
+
+	vertrag := bo4e.Vertrag{Sparte: sparte.GAS, Vertragsbeginn: pointer(start), Vertragsende: pointer(end)}
+	err = bo4e.ConvertVertrag(s.converter, &vertrag, invalidEnd)
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+	then.AssertThat(s.T(), *vertrag.Vertragsbeginn, is.EqualTo(start))
+	then.AssertThat(s.T(), *vertrag.Vertragsende, is.EqualTo(end))
+
+	energiemenge := bo4e.Energiemenge{Energieverbrauch: []bo4e.Verbrauch{{Startdatum: pointer(start), Enddatum: pointer(end)}}}
+	err = bo4e.ConvertEnergiemenge(s.converter, &energiemenge, sparte.GAS, invalidEnd)
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+	then.AssertThat(s.T(), *energiemenge.Energieverbrauch[0].Startdatum, is.EqualTo(start))
+	then.AssertThat(s.T(), *energiemenge.Energieverbrauch[0].Enddatum, is.EqualTo(end))
+}
@@ -0,0 +1,120 @@
+package mako_time_converter
+
+import (
+	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
+	"time"
+)
+
+// stromDate returns the German local date of the Stromtag to which the timestamp belongs
+func (l locationBasedGasTagConverter) stromDate(timestamp time.Time) (year int, month time.Month, day int) {
+	return l.toLocalTime(timestamp).Date()
+}
+
+// gasDate returns the German local date on which the Gastag to which the timestamp belongs starts
+func (l locationBasedGasTagConverter) gasDate(timestamp time.Time) (year int, month time.Month, day int) {
+	return l.gasDayStart(timestamp).In(l.gasDayLocation).Date()
+}
+
+// stromDayStartOn returns the start of the German Stromtag on the given German local date
+func (l locationBasedGasTagConverter) stromDayStartOn(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, l.location).UTC()
+}
+
+// firstMonthOfQuarter returns the first month of the calendar quarter to which the given month belongs
+func firstMonthOfQuarter(month time.Month) time.Month {
+	return ((month-1)/3)*3 + 1
+}
+
+// firstYearOfGasYear returns the calendar year in which the Gaswirtschaftsjahr that contains the given date begins
+func firstYearOfGasYear(year int, month time.Month) int {
+	if month < time.October {
+		return year - 1
+	}
+	return year
+}
+
+// StartOfStromMonth returns the start (German midnight) of the first Stromtag of the month to which the given timestamp belongs
+func (b Boundaries) StartOfStromMonth(timestamp time.Time) time.Time {
+	year, month, _ := b.converter.stromDate(timestamp)
+	return b.converter.stromDayStartOn(year, month, 1)
+}
+
+// EndOfStromMonth returns the exclusive end (German midnight of the first day of the next month) of the month to which the given timestamp belongs
+func (b Boundaries) EndOfStromMonth(timestamp time.Time) time.Time {
+	year, month, _ := b.converter.stromDate(timestamp)
+	return b.converter.stromDayStartOn(year, month+1, 1)
+}
+
+// StartOfGasMonth returns the start (German 6am) of the first Gastag of the gas month to which the given timestamp belongs. E.g. 2023-02-01 03:00 German local time still belongs to the gas month January.
+func (b Boundaries) StartOfGasMonth(timestamp time.Time) time.Time {
+	year, month, _ := b.converter.gasDate(timestamp)
+	return b.converter.gasDayStartOn(year, month, 1)
+}
+
+// EndOfGasMonth returns the exclusive end (German 6am of the first day of the next month) of the gas month to which the given timestamp belongs
+func (b Boundaries) EndOfGasMonth(timestamp time.Time) time.Time {
+	year, month, _ := b.converter.gasDate(timestamp)
+	return b.converter.gasDayStartOn(year, month+1, 1)
+}
+
+// StartOfStromQuarter returns the start (German midnight) of the calendar quarter to which the given timestamp belongs
+func (b Boundaries) StartOfStromQuarter(timestamp time.Time) time.Time {
+	year, month, _ := b.converter.stromDate(timestamp)
+	return b.converter.stromDayStartOn(year, firstMonthOfQuarter(month), 1)
+}
+
+// EndOfStromQuarter returns the exclusive end (German midnight) of the calendar quarter to which the given timestamp belongs
+func (b Boundaries) EndOfStromQuarter(timestamp time.Time) time.Time {
+	year, month, _ := b.converter.stromDate(timestamp)
+	return b.converter.stromDayStartOn(year, firstMonthOfQuarter(month)+3, 1)
+}
+
+// StartOfGasQuarter returns the start (German 6am) of the gas quarter to which the given timestamp belongs
+func (b Boundaries) StartOfGasQuarter(timestamp time.Time) time.Time {
+	year, month, _ := b.converter.gasDate(timestamp)
+	return b.converter.gasDayStartOn(year, firstMonthOfQuarter(month), 1)
+}
+
+// EndOfGasQuarter returns the exclusive end (German 6am) of the gas quarter to which the given timestamp belongs
+func (b Boundaries) EndOfGasQuarter(timestamp time.Time) time.Time {
+	year, month, _ := b.converter.gasDate(timestamp)
+	return b.converter.gasDayStartOn(year, firstMonthOfQuarter(month)+3, 1)
+}
+
+// StartOfStromYear returns the start (January 1st German midnight) of the calendar year to which the given timestamp belongs
+func (b Boundaries) StartOfStromYear(timestamp time.Time) time.Time {
+	year, _, _ := b.converter.stromDate(timestamp)
+	return b.converter.stromDayStartOn(year, time.January, 1)
+}
+
+// EndOfStromYear returns the exclusive end (January 1st German midnight of the next year) of the calendar year to which the given timestamp belongs
+func (b Boundaries) EndOfStromYear(timestamp time.Time) time.Time {
+	year, _, _ := b.converter.stromDate(timestamp)
+	return b.converter.stromDayStartOn(year+1, time.January, 1)
+}
+
+// StartOfGasYear returns the start (October 1st German 6am) of the Gaswirtschaftsjahr to which the given timestamp belongs
+func (b Boundaries) StartOfGasYear(timestamp time.Time) time.Time {
+	year, month, _ := b.converter.gasDate(timestamp)
+	return b.converter.gasDayStartOn(firstYearOfGasYear(year, month), time.October, 1)
+}
+
+// EndOfGasYear returns the exclusive end (October 1st German 6am of the next year) of the Gaswirtschaftsjahr to which the given timestamp belongs
+func (b Boundaries) EndOfGasYear(timestamp time.Time) time.Time {
+	year, month, _ := b.converter.gasDate(timestamp)
+	return b.converter.gasDayStartOn(firstYearOfGasYear(year, month)+1, time.October, 1)
+}
+
+// ConvertBoundary converts a boundary as it is returned by the StartOf... and EndOf... methods of the Boundaries (exclusive ends, gas boundaries at German 6am) to the given target configuration. The boundary is understood as an exclusive end iff target.IsEndDate is true; use the Gas methods for targets with IsGas and the Strom methods otherwise. The result is consistent with what GasTagConverter.Convert returns.
+func (b Boundaries) ConvertBoundary(boundary time.Time, target DateTimeConfiguration) (time.Time, error) {
+	source := DateTimeConfiguration{IsGas: target.IsGas, IsEndDate: target.IsEndDate}
+	if source.IsGas {
+		isGasTagAware := true
+		source.IsGasTagAware = &isGasTagAware
+	}
+	if source.IsEndDate {
+		exclusive := enddatetimekind.EXCLUSIVE
+		source.EndDateTimeKind = &exclusive
+	}
+	return b.converter.Convert(boundary, DateTimeConversionConfiguration{Source: source, Target: target})
+}
@@ -0,0 +1,74 @@
+package mako_time_converter_test
+
+import (
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/mako_time_converter"
+	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
+	"time"
+)
+
+func (s *Suite) Test_Strom_Boundaries() {
+	converter := getBerlinConverter()
+	boundaries := mako_time_converter.NewBoundaries(converter)
+	timestamp := time.Date(2023, 5, 15, 12, 0, 0, 0, time.UTC)
+	then.AssertThat(s.T(), boundaries.StartOfStromMonth(timestamp), is.EqualTo(time.Date(2023, 4, 30, 22, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), boundaries.EndOfStromMonth(timestamp), is.EqualTo(time.Date(2023, 5, 31, 22, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), boundaries.StartOfStromQuarter(timestamp), is.EqualTo(time.Date(2023, 3, 31, 22, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), boundaries.EndOfStromQuarter(timestamp), is.EqualTo(time.Date(2023, 6, 30, 22, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), boundaries.StartOfStromYear(timestamp), is.EqualTo(time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), boundaries.EndOfStromYear(timestamp), is.EqualTo(time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC)))
+	// 2023-12-31 23:30 UTC is already 2024 in German local time
+	then.AssertThat(s.T(), boundaries.StartOfStromMonth(time.Date(2023, 12, 31, 23, 30, 0, 0, time.UTC)), is.EqualTo(time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), boundaries.EndOfStromQuarter(time.Date(2023, 11, 15, 12, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC)))
+}
+
+func (s *Suite) Test_Gas_Boundaries() {
+	converter := getBerlinConverter()
+	boundaries := mako_time_converter.NewBoundaries(converter)
+	timestamp := time.Date(2023, 5, 15, 12, 0, 0, 0, time.UTC)
+	then.AssertThat(s.T(), boundaries.StartOfGasMonth(timestamp), is.EqualTo(time.Date(2023, 5, 1, 4, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), boundaries.EndOfGasMonth(timestamp), is.EqualTo(time.Date(2023, 6, 1, 4, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), boundaries.StartOfGasQuarter(timestamp), is.EqualTo(time.Date(2023, 4, 1, 4, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), boundaries.EndOfGasQuarter(timestamp), is.EqualTo(time.Date(2023, 7, 1, 4, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), boundaries.StartOfGasYear(timestamp), is.EqualTo(time.Date(2022, 10, 1, 4, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), boundaries.EndOfGasYear(timestamp), is.EqualTo(time.Date(2023, 10, 1, 4, 0, 0, 0, time.UTC)))
+	// 2023-10-01 03:00 German local time still belongs to the gas year 2022/2023 and to the gas month September
+	earlyMorning := time.Date(2023, 10, 1, 1, 0, 0, 0, time.UTC)
+	then.AssertThat(s.T(), boundaries.StartOfGasYear(earlyMorning), is.EqualTo(time.Date(2022, 10, 1, 4, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), boundaries.StartOfGasMonth(earlyMorning), is.EqualTo(time.Date(2023, 9, 1, 4, 0, 0, 0, time.UTC)))
+	// the gas year 2023/2024 starts at 2023-10-01 06:00 CEST and ends at 2024-10-01 06:00 CEST
+	then.AssertThat(s.T(), boundaries.StartOfGasYear(time.Date(2023, 10, 1, 4, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2023, 10, 1, 4, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), boundaries.EndOfGasYear(time.Date(2024, 2, 1, 4, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2024, 10, 1, 4, 0, 0, 0, time.UTC)))
+	// gas months in winter start at 05:00 UTC
+	then.AssertThat(s.T(), boundaries.EndOfGasMonth(time.Date(2023, 11, 15, 0, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2023, 12, 1, 5, 0, 0, 0, time.UTC)))
+}
+
+func (s *Suite) Test_ConvertBoundary_Is_Consistent_With_Convert() {
+	converter := getBerlinConverter()
+	boundaries := mako_time_converter.NewBoundaries(converter)
+	timestamp := time.Date(2023, 5, 15, 12, 0, 0, 0, time.UTC)
+	inclusiveNonGasTagAwareEnd := mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(false), IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.INCLUSIVE)}
+	actual, err := boundaries.ConvertBoundary(boundaries.EndOfGasMonth(timestamp), inclusiveNonGasTagAwareEnd)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), actual, is.EqualTo(time.Date(2023, 5, 30, 22, 0, 0, 0, time.UTC))) // 2023-05-31 German local date
+	expected, err := converter.Convert(boundaries.EndOfGasMonth(timestamp), mako_time_converter.DateTimeConversionConfiguration{
+		Source: mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(true), IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.EXCLUSIVE)},
+		Target: inclusiveNonGasTagAwareEnd,
+	})
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), actual, is.EqualTo(expected))
+
+	nonGasTagAwareStart := mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(false)}
+	actual, err = boundaries.ConvertBoundary(boundaries.StartOfGasMonth(timestamp), nonGasTagAwareStart)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), actual, is.EqualTo(time.Date(2023, 4, 30, 22, 0, 0, 0, time.UTC)))
+
+	inclusiveStromEnd := mako_time_converter.DateTimeConfiguration{IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.INCLUSIVE)}
+	actual, err = boundaries.ConvertBoundary(boundaries.EndOfStromYear(timestamp), inclusiveStromEnd)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), actual, is.EqualTo(time.Date(2023, 12, 30, 23, 0, 0, 0, time.UTC)))
+
+	_, err = boundaries.ConvertBoundary(timestamp, mako_time_converter.DateTimeConfiguration{IsGas: true}) // no gas tag awareness given
+	then.AssertThat(s.T(), err, is.Not(is.Nil()))
+}
@@ -0,0 +1,95 @@
+package mako_time_converter
+
+import (
+	"errors"
+	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
+)
+
+// DateTimeConfigurationBuilder builds a DateTimeConfiguration without the need to take the address of bools and EndDateTimeKinds.
+// Inconsistent combinations are reported by Build, not only when the configuration is used for a conversion.
+type DateTimeConfigurationBuilder struct {
+	configuration DateTimeConfiguration
+}
+
+// NewDateTimeConfiguration returns a builder for a DateTimeConfiguration that describes a (non Gas) start date, e.g.
+//
+//	NewDateTimeConfiguration().Gas().GasTagAware().EndDate(enddatetimekind.EXCLUSIVE).Build()
+func NewDateTimeConfiguration() *DateTimeConfigurationBuilder {
+	return &DateTimeConfigurationBuilder{}
+}
+
+// Gas marks the date time as date time of Sparte Gas. Either GasTagAware or NotGasTagAware has to be called, too.
+func (b *DateTimeConfigurationBuilder) Gas() *DateTimeConfigurationBuilder {
+	b.configuration.IsGas = true
+	return b
+}
+
+// GasTagAware marks the date time as aware of the German Gas-Tag (requires Gas)
+func (b *DateTimeConfigurationBuilder) GasTagAware() *DateTimeConfigurationBuilder {
+	isGasTagAware := true
+	b.configuration.IsGasTagAware = &isGasTagAware
+	return b
+}
+
+// NotGasTagAware marks the date time as unaware of the German Gas-Tag, meaning that Gas days start at German midnight (requires Gas)
+func (b *DateTimeConfigurationBuilder) NotGasTagAware() *DateTimeConfigurationBuilder {
+	isGasTagAware := false
+	b.configuration.IsGasTagAware = &isGasTagAware
+	return b
+}
+
+// EndDate marks the date time as end date of the given kind
+func (b *DateTimeConfigurationBuilder) EndDate(kind enddatetimekind.EndDateTimeKind) *DateTimeConfigurationBuilder {
+	b.configuration.IsEndDate = true
+	b.configuration.EndDateTimeKind = &kind
+	return b
+}
+
+// StripTime removes the time of day from the date time (see DateTimeConfiguration.StripTime)
+func (b *DateTimeConfigurationBuilder) StripTime() *DateTimeConfigurationBuilder {
+	b.configuration.StripTime = true
+	return b
+}
+
+// Build returns the configuration or an InvalidConfigurationError if the combination of settings is inconsistent (e.g. GasTagAware without Gas or Gas without a decision on the Gas-Tag awareness)
+func (b *DateTimeConfigurationBuilder) Build() (DateTimeConfiguration, error) {
+	if b.configuration.IsGasTagAware != nil && !b.configuration.IsGas {
+		return DateTimeConfiguration{}, InvalidConfigurationError{
+			Field: "IsGasTagAware",
+			Rule:  "excluded_unless",
+			Err:   errors.New("the Gas-Tag awareness may only be set for Gas"),
+		}
+	}
+	if b.configuration.StripTime && b.configuration.hasTimeOfDayEndDate() {
+		return DateTimeConfiguration{}, InvalidConfigurationError{
+			Field: "StripTime",
+			Rule:  timeOfDayEndDateRule,
+			Err:   errors.New("the time may not be stripped from an end date of kind " + b.configuration.EndDateTimeKind.String()),
+		}
+	}
+	if err := configurationValidator.Struct(b.configuration); err != nil {
+		return DateTimeConfiguration{}, newInvalidConfigurationError(err)
+	}
+	return b.configuration.clone(), nil
+}
+
+// DateTimeConversionConfigurationBuilder builds a DateTimeConversionConfiguration from a source and a target DateTimeConfiguration
+type DateTimeConversionConfigurationBuilder struct {
+	source DateTimeConfiguration
+}
+
+// From returns a builder for a DateTimeConversionConfiguration with the given source, e.g.
+//
+//	From(MaKoStandardGas()).To(InclusiveDateOnlyGas())
+func From(source DateTimeConfiguration) *DateTimeConversionConfigurationBuilder {
+	return &DateTimeConversionConfigurationBuilder{source: source}
+}
+
+// To returns the configuration that converts from the source to the given target or an InvalidConfigurationError if source and target cannot be combined (e.g. Gas and Strom)
+func (b *DateTimeConversionConfigurationBuilder) To(target DateTimeConfiguration) (DateTimeConversionConfiguration, error) {
+	result := DateTimeConversionConfiguration{Source: b.source.clone(), Target: target.clone()}
+	if err := validateConfiguration(result); err != nil {
+		return DateTimeConversionConfiguration{}, err
+	}
+	return result, nil
+}
@@ -0,0 +1,45 @@
+package mako_time_converter_test
+
+import (
+	"errors"
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/mako_time_converter"
+	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
+)
+
+func (s *Suite) Test_DateTimeConfigurationBuilder() {
+	configuration, err := mako_time_converter.NewDateTimeConfiguration().Gas().GasTagAware().EndDate(enddatetimekind.EXCLUSIVE).Build()
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), configuration, is.EqualTo(mako_time_converter.MaKoStandardGas()))
+
+	configuration, err = mako_time_converter.NewDateTimeConfiguration().Gas().NotGasTagAware().EndDate(enddatetimekind.INCLUSIVE).StripTime().Build()
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), configuration, is.EqualTo(mako_time_converter.InclusiveDateOnlyGas()))
+
+	configuration, err = mako_time_converter.NewDateTimeConfiguration().Build()
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), configuration, is.EqualTo(mako_time_converter.DateTimeConfiguration{}))
+}
+
+func (s *Suite) Test_DateTimeConfigurationBuilder_Inconsistent_Combinations() {
+	_, err := mako_time_converter.NewDateTimeConfiguration().GasTagAware().Build()
+	var invalidConfigurationError mako_time_converter.InvalidConfigurationError
+	then.AssertThat(s.T(), errors.As(err, &invalidConfigurationError), is.True())
+	then.AssertThat(s.T(), invalidConfigurationError.Field, is.EqualTo("IsGasTagAware"))
+	then.AssertThat(s.T(), invalidConfigurationError.Rule, is.EqualTo("excluded_unless"))
+
+	_, err = mako_time_converter.NewDateTimeConfiguration().Gas().Build()
+	then.AssertThat(s.T(), errors.As(err, &invalidConfigurationError), is.True())
+	then.AssertThat(s.T(), invalidConfigurationError.Field, is.EqualTo("IsGasTagAware"))
+	then.AssertThat(s.T(), invalidConfigurationError.Rule, is.EqualTo("required_if"))
+}
+
+func (s *Suite) Test_DateTimeConversionConfigurationBuilder() {
+	configuration, err := mako_time_converter.From(mako_time_converter.InclusiveDateOnlyGas()).To(mako_time_converter.MaKoStandardGas())
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), configuration, is.EqualTo(mako_time_converter.DateTimeConversionConfiguration{Source: mako_time_converter.InclusiveDateOnlyGas(), Target: mako_time_converter.MaKoStandardGas()}))
+
+	_, err = mako_time_converter.From(mako_time_converter.MaKoStandardGas()).To(mako_time_converter.MaKoStandardStrom())
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+}
@@ -0,0 +1,32 @@
+// Command makotime-server serves the JSON endpoints of the server package (see server.NewHandler).
+//
+// Usage:
+//
+//	makotime-server [-addr :8080] [-zone Europe/Berlin]
+package main
+
+import (
+	"flag"
+	"github.com/hochfrequenz/mako_time_converter"
+	"github.com/hochfrequenz/mako_time_converter/server"
+	"log"
+	"net/http"
+	"time"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "the address to listen on")
+	zoneName := flag.String("zone", "Europe/Berlin", "the timezone of the German local time")
+	flag.Parse()
+	converter, err := mako_time_converter.NewGasTagConverterE(*zoneName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           server.NewHandler(converter),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Printf("listening on %s", *addr)
+	log.Fatal(httpServer.ListenAndServe())
+}
@@ -0,0 +1,247 @@
+// Command makotime converts timestamps between the date time semantics of different systems in German Marktkommunikation (MaKo).
+//
+// Usage:
+//
+//	makotime [flags] [timestamp ...]
+//
+// The timestamps are RFC 3339 date times (e.g. 2023-01-01T05:00:00Z) or dates (e.g. 2023-01-01, which are understood as German midnight).
+// If no timestamp is given as argument, newline separated timestamps are read from stdin; use -csv-column to read a column of CSV data from stdin instead.
+// For each timestamp, the converted value is printed in UTC and in German local time.
+//
+// The conversion is either read from a JSON file (-config) that has the JSON format of a DateTimeConversionConfiguration, e.g.
+//
+//	{"source":{"isGas":true,"isGasTagAware":false,"isEndDate":true,"endDateTimeKind":"INCLUSIVE"},"target":{"isGas":true,"isGasTagAware":true,"isEndDate":true,"endDateTimeKind":"EXCLUSIVE"}}
+//
+// or described by flags, e.g.
+//
+//	makotime -gas -source-end INCLUSIVE -target-gastag-aware -target-end EXCLUSIVE 2022-12-31T23:00:00Z
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/hochfrequenz/mako_time_converter"
+	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// sideFlags are the flags that describe one side (source or target) of a conversion
+type sideFlags struct {
+	isGasTagAware bool
+	endDateKind   string
+	stripTime     bool
+}
+
+func (f *sideFlags) register(flagSet *flag.FlagSet, side string) {
+	flagSet.BoolVar(&f.isGasTagAware, side+"-gastag-aware", false, "the "+side+" is aware of the German Gas-Tag (requires -gas)")
+	flagSet.StringVar(&f.endDateKind, side+"-end", "", "the "+side+" is an end date of the given kind (INCLUSIVE, EXCLUSIVE, INCLUSIVE_LAST_SECOND, INCLUSIVE_LAST_MILLISECOND, INCLUSIVE_LAST_QUARTER_HOUR or INCLUSIVE_LAST_HOUR)")
+	flagSet.BoolVar(&f.stripTime, side+"-strip", false, "strip the time of the "+side)
+}
+
+func (f *sideFlags) configuration(isGas bool) (mako_time_converter.DateTimeConfiguration, error) {
+	result := mako_time_converter.DateTimeConfiguration{IsGas: isGas, StripTime: f.stripTime}
+	if isGas {
+		isGasTagAware := f.isGasTagAware
+		result.IsGasTagAware = &isGasTagAware
+	} else if f.isGasTagAware {
+		return result, errors.New("gas tag awareness requires -gas")
+	}
+	if f.endDateKind != "" {
+		var kind enddatetimekind.EndDateTimeKind
+		if err := json.Unmarshal([]byte(`"`+strings.ToUpper(f.endDateKind)+`"`), &kind); err != nil {
+			return result, err
+		}
+		result.IsEndDate = true
+		result.EndDateTimeKind = &kind
+	}
+	return result, nil
+}
+
+// run executes makotime with the given command line arguments and returns the exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flagSet := flag.NewFlagSet("makotime", flag.ContinueOnError)
+	flagSet.SetOutput(stderr)
+	zoneName := flagSet.String("zone", "Europe/Berlin", "the timezone of the German local time")
+	configPath := flagSet.String("config", "", "path to a JSON file with the DateTimeConversionConfiguration (overrides all other conversion flags)")
+	isGas := flagSet.Bool("gas", false, "source and target are Sparte Gas")
+	invert := flagSet.Bool("invert", false, "invert the conversion (switch source and target)")
+	csvColumn := flagSet.Int("csv-column", 0, "read CSV from stdin and convert the given (1-based) column")
+	csvHeader := flagSet.Bool("csv-header", false, "the first CSV record is a header which is not converted")
+	var source, target sideFlags
+	source.register(flagSet, "source")
+	target.register(flagSet, "target")
+	if err := flagSet.Parse(args); err != nil {
+		return 2
+	}
+	converter, err := mako_time_converter.NewGasTagConverterE(*zoneName)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	var configuration mako_time_converter.DateTimeConversionConfiguration
+	if *configPath != "" {
+		configuration, err = readConfiguration(*configPath)
+	} else {
+		configuration, err = configurationFromFlags(*isGas, &source, &target)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	if *invert {
+		configuration = configuration.Invert()
+	}
+	plan, err := converter.Compile(configuration)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	c := conversion{converter: converter, plan: plan}
+	switch {
+	case flagSet.NArg() > 0:
+		err = c.convertLines(flagSet.Args(), stdout)
+	case *csvColumn > 0:
+		err = c.convertCsv(stdin, *csvColumn-1, *csvHeader, stdout)
+	default:
+		var lines []string
+		if lines, err = readLines(stdin); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+		err = c.convertLines(lines, stdout)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return 0
+}
+
+func readConfiguration(path string) (mako_time_converter.DateTimeConversionConfiguration, error) {
+	var configuration mako_time_converter.DateTimeConversionConfiguration
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return configuration, err
+	}
+	err = json.Unmarshal(content, &configuration)
+	return configuration, err
+}
+
+func configurationFromFlags(isGas bool, source, target *sideFlags) (mako_time_converter.DateTimeConversionConfiguration, error) {
+	sourceConfiguration, err := source.configuration(isGas)
+	if err != nil {
+		return mako_time_converter.DateTimeConversionConfiguration{}, fmt.Errorf("source: %w", err)
+	}
+	targetConfiguration, err := target.configuration(isGas)
+	if err != nil {
+		return mako_time_converter.DateTimeConversionConfiguration{}, fmt.Errorf("target: %w", err)
+	}
+	return mako_time_converter.DateTimeConversionConfiguration{Source: sourceConfiguration, Target: targetConfiguration}, nil
+}
+
+// maxLineLength is the maximum length of a line read from stdin in bytes
+const maxLineLength = 1 << 20
+
+// readLines returns all non-empty lines of the reader. It returns an error if the reader fails or a line is longer than maxLineLength.
+func readLines(reader io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineLength)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading stdin failed: %w", err)
+	}
+	return lines, nil
+}
+
+// conversion converts and prints timestamps
+type conversion struct {
+	converter mako_time_converter.GasTagConverter
+	plan      mako_time_converter.ConversionPlan
+}
+
+// parse parses an RFC 3339 date time or a date (German midnight)
+func (c conversion) parse(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if timestamp, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return timestamp, nil
+	}
+	timestamp, err := time.ParseInLocation(time.DateOnly, value, c.converter.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("'%s' is neither an RFC 3339 date time nor a date", value)
+	}
+	return timestamp, nil
+}
+
+// convert returns the converted timestamp in UTC and in German local time
+func (c conversion) convert(value string) (utc string, local string, err error) {
+	timestamp, err := c.parse(value)
+	if err != nil {
+		return "", "", err
+	}
+	result, err := c.plan.Apply(timestamp)
+	if err != nil {
+		return "", "", err
+	}
+	return result.Format(time.RFC3339), result.In(c.converter.Location()).Format(time.RFC3339), nil
+}
+
+func (c conversion) convertLines(values []string, writer io.Writer) error {
+	for _, value := range values {
+		utc, local, err := c.convert(value)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", value, utc, local)
+	}
+	return nil
+}
+
+// convertCsv converts the given column of all CSV records and writes the records with two additional columns (UTC and German local time)
+func (c conversion) convertCsv(reader io.Reader, column int, hasHeader bool, writer io.Writer) error {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvWriter := csv.NewWriter(writer)
+	for isHeader := hasHeader; ; isHeader = false {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if isHeader {
+			if err = csvWriter.Write(append(record, "utc", "local")); err != nil {
+				return err
+			}
+			continue
+		}
+		if column >= len(record) {
+			return fmt.Errorf("the record %v has no column %d", record, column+1)
+		}
+		utc, local, err := c.convert(record[column])
+		if err != nil {
+			return err
+		}
+		if err = csvWriter.Write(append(record, utc, local)); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
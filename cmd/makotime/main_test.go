@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/stretchr/testify/suite"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+type Suite struct {
+	suite.Suite
+}
+
+func TestInit(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+// execute runs makotime and returns the exit code, stdout and stderr
+func execute(args []string, stdin string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	exitCode := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return exitCode, stdout.String(), stderr.String()
+}
+
+func (s *Suite) Test_Convert_Arguments_With_Flags() {
+	exitCode, stdout, stderr := execute([]string{"-gas", "-source-end", "INCLUSIVE", "-target-gastag-aware", "-target-end", "exclusive", "2022-12-31T23:00:00Z", "2023-05-31"}, "")
+	then.AssertThat(s.T(), stderr, is.EqualTo(""))
+	then.AssertThat(s.T(), exitCode, is.EqualTo(0))
+	then.AssertThat(s.T(), stdout, is.EqualTo("2022-12-31T23:00:00Z\t2023-01-02T05:00:00Z\t2023-01-02T06:00:00+01:00\n2023-05-31\t2023-06-01T04:00:00Z\t2023-06-01T06:00:00+02:00\n"))
+}
+
+func (s *Suite) Test_Convert_Stdin_Lines_With_Config_File() {
+	configPath := filepath.Join(s.T().TempDir(), "config.json")
+	config := `{"source":{"isGas":true,"isGasTagAware":true,"isEndDate":true,"endDateTimeKind":"EXCLUSIVE"},"target":{"isGas":true,"isGasTagAware":false,"isEndDate":true,"endDateTimeKind":"INCLUSIVE"}}`
+	then.AssertThat(s.T(), os.WriteFile(configPath, []byte(config), 0o600), is.Nil())
+	exitCode, stdout, _ := execute([]string{"-config", configPath}, "2023-01-02T05:00:00Z\n\n2023-06-01T04:00:00Z\n")
+	then.AssertThat(s.T(), exitCode, is.EqualTo(0))
+	then.AssertThat(s.T(), stdout, is.EqualTo("2023-01-02T05:00:00Z\t2022-12-31T23:00:00Z\t2023-01-01T00:00:00+01:00\n2023-06-01T04:00:00Z\t2023-05-30T22:00:00Z\t2023-05-31T00:00:00+02:00\n"))
+
+	exitCode, stdout, _ = execute([]string{"-config", configPath, "-invert"}, "2022-12-31T23:00:00Z\n")
+	then.AssertThat(s.T(), exitCode, is.EqualTo(0))
+	then.AssertThat(s.T(), stdout, is.EqualTo("2022-12-31T23:00:00Z\t2023-01-02T05:00:00Z\t2023-01-02T06:00:00+01:00\n"))
+}
+
+func (s *Suite) Test_Convert_Csv_Column() {
+	exitCode, stdout, stderr := execute([]string{"-source-end", "INCLUSIVE", "-target-end", "EXCLUSIVE", "-csv-column", "2", "-csv-header"}, "id,end\nA,2023-01-31\nB,2023-02-28T23:00:00Z\n")
+	then.AssertThat(s.T(), stderr, is.EqualTo(""))
+	then.AssertThat(s.T(), exitCode, is.EqualTo(0))
+	then.AssertThat(s.T(), stdout, is.EqualTo("id,end,utc,local\nA,2023-01-31,2023-01-31T23:00:00Z,2023-02-01T00:00:00+01:00\nB,2023-02-28T23:00:00Z,2023-03-01T23:00:00Z,2023-03-02T00:00:00+01:00\n"))
+
+	exitCode, _, stderr = execute([]string{"-csv-column", "3"}, "A,2023-01-31\n")
+	then.AssertThat(s.T(), exitCode, is.EqualTo(1))
+	then.AssertThat(s.T(), stderr, is.StringContaining("has no column 3"))
+}
+
+func (s *Suite) Test_Invalid_Usage() {
+	invalidArgs := map[string][]string{
+		"gas tag awareness requires -gas": {"-source-gastag-aware", "2023-01-01"},
+		"invalid EndDateTimeKind":         {"-target-end", "SOMETIMES", "2023-01-01"},
+		"no such file":                    {"-config", filepath.Join(s.T().TempDir(), "missing.json"), "2023-01-01"},
+		"flag provided but not defined":   {"-unknown"},
+	}
+	for expectedMessage, args := range invalidArgs {
+		exitCode, _, stderr := execute(args, "")
+		then.AssertThat(s.T(), exitCode, is.EqualTo(2))
+		then.AssertThat(s.T(), stderr, is.StringContaining(expectedMessage))
+	}
+	exitCode, _, stderr := execute([]string{"-zone", "OtherContinent/IDontKnow", "2023-01-01"}, "")
+	then.AssertThat(s.T(), exitCode, is.EqualTo(1))
+	then.AssertThat(s.T(), stderr, is.StringContaining("timezone data missing"))
+	exitCode, _, stderr = execute([]string{"yesterday"}, "")
+	then.AssertThat(s.T(), exitCode, is.EqualTo(1))
+	then.AssertThat(s.T(), stderr, is.StringContaining("'yesterday' is neither"))
+}
+
+func (s *Suite) Test_Stdin_Read_Errors() {
+	var stdout, stderr bytes.Buffer
+	exitCode := run(nil, io.MultiReader(strings.NewReader("2023-01-01\n"), iotest.ErrReader(errors.New("broken pipe"))), &stdout, &stderr)
+	then.AssertThat(s.T(), exitCode, is.EqualTo(2))
+	then.AssertThat(s.T(), stdout.String(), is.EqualTo(""))
+	then.AssertThat(s.T(), stderr.String(), is.StringContaining("broken pipe"))
+
+	var output, errorOutput string
+	exitCode, output, errorOutput = execute(nil, "2023-01-01\n"+strings.Repeat("x", maxLineLength+1)+"\n2023-01-02\n")
+	then.AssertThat(s.T(), exitCode, is.EqualTo(2))
+	then.AssertThat(s.T(), output, is.EqualTo(""))
+	then.AssertThat(s.T(), errorOutput, is.StringContaining("token too long"))
+}
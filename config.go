@@ -3,6 +3,7 @@ package mako_time_converter
 import (
 	"github.com/go-playground/validator/v10"
 	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
+	"github.com/hochfrequenz/mako_time_converter/sparte"
 )
 
 // DateTimeConfiguration describes how a time.Time is meant/interpreted by a system. Two of these configurations allow to convert a time.Time smoothly.
@@ -17,6 +18,8 @@ type DateTimeConfiguration struct {
 	IsGasTagAware *bool `json:"isGasTagAware,omitempty" validate:"required_if=IsGas true"`
 	// Set true to remove all hours, minutes, seconds, milliseconds from the respective time.Time. If set in the DateTimeConversionConfiguration.Source the hours, minutes... will be stripped _before_ the conversion. If set in the DateTimeConversionConfiguration.Target the hours, minutes... will be stripped _after_ the conversion.
 	StripTime bool `json:"stripTime"`
+	// Sparte optionally binds the configuration to a sparte.Sparte. If set, IsGas must be true iff the Sparte is sparte.GAS. Source and Target must not be bound to different Sparten.
+	Sparte *sparte.Sparte `json:"sparte,omitempty"`
 }
 
 // A DateTimeConversionConfiguration describes which steps are necessary to convert a datetime from a Source to a Target
@@ -35,9 +38,44 @@ func (dtcc *DateTimeConversionConfiguration) Invert() DateTimeConversionConfigur
 	}
 }
 
+// hasTimeOfDayEndDate returns true if the configuration describes an end date whose kind depends on the time of day (e.g. enddatetimekind.INCLUSIVE_LAST_SECOND)
+func (dtc DateTimeConfiguration) hasTimeOfDayEndDate() bool {
+	return dtc.IsEndDate && dtc.EndDateTimeKind != nil && *dtc.EndDateTimeKind != enddatetimekind.INCLUSIVE && *dtc.EndDateTimeKind != enddatetimekind.EXCLUSIVE
+}
+
 func DateTimeConversionConfigurationStructLevelValidator(sl validator.StructLevel) {
 	config := sl.Current().Interface().(DateTimeConversionConfiguration)
 	if config.Source.IsGas != config.Target.IsGas {
 		sl.ReportError(config.Source, "Source/Target.IsGas", "Target", "Source.IsGas==Target.IsGas", "")
 	}
+	// stripping the time of an end date like 23:59:59 would turn it into a different day
+	if config.Source.StripTime && config.Source.hasTimeOfDayEndDate() {
+		sl.ReportError(config.Source.StripTime, "Source.StripTime", "StripTime", timeOfDayEndDateRule, "")
+	}
+	if config.Target.StripTime && config.Target.hasTimeOfDayEndDate() {
+		sl.ReportError(config.Target.StripTime, "Target.StripTime", "StripTime", timeOfDayEndDateRule, "")
+	}
+	if !config.Source.matchesSparte() {
+		sl.ReportError(config.Source.Sparte, "Source.Sparte", "Sparte", sparteRule, "")
+	}
+	if !config.Target.matchesSparte() {
+		sl.ReportError(config.Target.Sparte, "Target.Sparte", "Sparte", sparteRule, "")
+	}
+	if config.Source.Sparte != nil && config.Target.Sparte != nil && *config.Source.Sparte != *config.Target.Sparte {
+		sl.ReportError(config.Target.Sparte, "Source/Target.Sparte", "Sparte", "Source.Sparte==Target.Sparte", "")
+	}
+}
+
+// matchesSparte returns true if the configuration is not bound to a Sparte or if IsGas matches the Sparte (only sparte.GAS is gas; all other Sparten use calendar days like Strom)
+func (dtc DateTimeConfiguration) matchesSparte() bool {
+	if dtc.Sparte == nil {
+		return true
+	}
+	return dtc.Sparte.IsValid() && dtc.IsGas == (*dtc.Sparte == sparte.GAS)
 }
+
+// timeOfDayEndDateRule is the rule that is violated if StripTime is combined with an end date kind that depends on the time of day
+const timeOfDayEndDateRule = "excluded_with_time_of_day_end_date"
+
+// sparteRule is the rule that is violated if the Sparte of a configuration is unknown or does not match IsGas
+const sparteRule = "matches_sparte"
@@ -24,3 +24,16 @@ func (s *Suite) Test_Configuration_Serialization() {
 		then.AssertThat(s.T(), config, is.EqualTo(deserializedConfig))
 	}
 }
+
+func (s *Suite) Test_EndDateTimeKind_Serialization() {
+	for _, kind := range []enddatetimekind.EndDateTimeKind{enddatetimekind.INCLUSIVE, enddatetimekind.EXCLUSIVE, enddatetimekind.INCLUSIVE_LAST_SECOND, enddatetimekind.INCLUSIVE_LAST_MILLISECOND, enddatetimekind.INCLUSIVE_LAST_QUARTER_HOUR, enddatetimekind.INCLUSIVE_LAST_HOUR} {
+		jsonBytes, err := json.Marshal(kind)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), string(jsonBytes), is.EqualTo(`"`+kind.String()+`"`))
+		var deserializedKind enddatetimekind.EndDateTimeKind
+		err = json.Unmarshal(jsonBytes, &deserializedKind)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), deserializedKind, is.EqualTo(kind))
+	}
+	then.AssertThat(s.T(), enddatetimekind.INCLUSIVE_LAST_QUARTER_HOUR.String(), is.EqualTo("INCLUSIVE_LAST_QUARTER_HOUR"))
+}
@@ -0,0 +1,184 @@
+// Package dtm parses and formats the values of EDIFACT DTM segments as they are used in German Marktkommunikation (MaKo) messages.
+package dtm
+
+import (
+	"errors"
+	"fmt"
+	"github.com/hochfrequenz/mako_time_converter"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormatCode is the EDIFACT "Date or time or period format code" (data element 2379) of a DTM segment
+type FormatCode string
+
+const (
+	// Format102 is a date: CCYYMMDD
+	Format102 FormatCode = "102"
+	// Format203 is a date and time: CCYYMMDDHHMM
+	Format203 FormatCode = "203"
+	// Format303 is a date and time with UTC offset: CCYYMMDDHHMMZZZ, e.g. 202301010500?+00
+	Format303 FormatCode = "303"
+	// Format718 is a period of dates: CCYYMMDD-CCYYMMDD
+	Format718 FormatCode = "718"
+	// Format719 is a period of date times: CCYYMMDDHHMM-CCYYMMDDHHMM
+	Format719 FormatCode = "719"
+	// Format802 is a duration in months, e.g. 3
+	Format802 FormatCode = "802"
+)
+
+const (
+	layout102 = "20060102"
+	layout203 = "200601021504"
+	// releaseCharacter is the EDIFACT release (escape) character, e.g. in "?+00"
+	releaseCharacter = '?'
+)
+
+// ErrInvalidValue is returned if a DTM value does not match its format code
+var ErrInvalidValue = errors.New("invalid DTM value")
+
+// ErrUnsupportedFormat is returned if a format code is not supported by the respective function
+var ErrUnsupportedFormat = errors.New("unsupported DTM format code")
+
+// unescape removes the EDIFACT release characters, e.g. "202301010500?+00" becomes "202301010500+00"
+func unescape(value string) string {
+	var builder strings.Builder
+	released := false
+	for _, r := range value {
+		if r == releaseCharacter && !released {
+			released = true
+			continue
+		}
+		released = false
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// Parse parses a DTM value with format code 102, 203 or 303. Values of format 102 and 203 carry no UTC offset and are interpreted as local times in the given location (usually German local time). Values of format 303 may contain the UTC offset either escaped ("?+00") or unescaped ("+00"). The result is always UTC.
+// It returns mako_time_converter.ErrNilLocation if the location is nil.
+func Parse(value string, format FormatCode, location *time.Location) (time.Time, error) {
+	if location == nil {
+		return time.Time{}, mako_time_converter.ErrNilLocation
+	}
+	value = unescape(value)
+	var result time.Time
+	var err error
+	switch format {
+	case Format102:
+		result, err = time.ParseInLocation(layout102, value, location)
+	case Format203:
+		result, err = time.ParseInLocation(layout203, value, location)
+	case Format303:
+		result, err = parse303(value)
+	default:
+		return time.Time{}, fmt.Errorf("%w: %s cannot be parsed as a single point in time", ErrUnsupportedFormat, format)
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: '%s' does not match format %s: %w", ErrInvalidValue, value, format, err)
+	}
+	return result.UTC(), nil
+}
+
+// maxOffsetHours is the largest UTC offset (in hours) of a time zone
+const maxOffsetHours = 14
+
+// parse303 parses an unescaped value of format CCYYMMDDHHMMZZZ where ZZZ is the sign and the hours of the UTC offset
+func parse303(value string) (time.Time, error) {
+	if len(value) != len(layout203)+3 {
+		return time.Time{}, fmt.Errorf("expected %d characters but got %d", len(layout203)+3, len(value))
+	}
+	dateTime, offset := value[:len(layout203)], value[len(layout203):]
+	if offset[0] != '+' && offset[0] != '-' {
+		return time.Time{}, fmt.Errorf("the UTC offset '%s' has no sign", offset)
+	}
+	offsetHours, err := strconv.Atoi(offset)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if offsetHours < -maxOffsetHours || offsetHours > maxOffsetHours {
+		return time.Time{}, fmt.Errorf("the UTC offset '%s' is not between -%d and +%d hours", offset, maxOffsetHours, maxOffsetHours)
+	}
+	return time.ParseInLocation(layout203, dateTime, time.FixedZone("", offsetHours*60*60))
+}
+
+// Format formats the given timestamp as DTM value with format code 102, 203 or 303. Values of format 102 and 203 are formatted in the given location (usually German local time). Values of format 303 are always formatted in UTC with escaped offset, e.g. "202301010500?+00".
+// It returns mako_time_converter.ErrNilLocation if the location is nil.
+func Format(timestamp time.Time, format FormatCode, location *time.Location) (string, error) {
+	if location == nil {
+		return "", mako_time_converter.ErrNilLocation
+	}
+	switch format {
+	case Format102:
+		return timestamp.In(location).Format(layout102), nil
+	case Format203:
+		return timestamp.In(location).Format(layout203), nil
+	case Format303:
+		return timestamp.UTC().Format(layout203) + "?+00", nil
+	default:
+		return "", fmt.Errorf("%w: %s cannot be formatted as a single point in time", ErrUnsupportedFormat, format)
+	}
+}
+
+// periodFormat returns the format of the start and the end of a period format code (718 or 719)
+func periodFormat(format FormatCode) (FormatCode, error) {
+	switch format {
+	case Format718:
+		return Format102, nil
+	case Format719:
+		return Format203, nil
+	default:
+		return "", fmt.Errorf("%w: %s is not a period", ErrUnsupportedFormat, format)
+	}
+}
+
+// ParsePeriod parses a DTM value with format code 718 or 719 into its start and end (both UTC). The values are interpreted as local times in the given location (usually German local time).
+func ParsePeriod(value string, format FormatCode, location *time.Location) (start time.Time, end time.Time, err error) {
+	pointFormat, err := periodFormat(format)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	startValue, endValue, found := strings.Cut(unescape(value), "-")
+	if !found {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: '%s' is not a period of format %s", ErrInvalidValue, value, format)
+	}
+	if start, err = Parse(startValue, pointFormat, location); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if end, err = Parse(endValue, pointFormat, location); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return start, end, nil
+}
+
+// FormatPeriod formats start and end as DTM value with format code 718 or 719 in the given location (usually German local time)
+func FormatPeriod(start, end time.Time, format FormatCode, location *time.Location) (string, error) {
+	pointFormat, err := periodFormat(format)
+	if err != nil {
+		return "", err
+	}
+	startValue, err := Format(start, pointFormat, location)
+	if err != nil {
+		return "", err
+	}
+	endValue, err := Format(end, pointFormat, location)
+	if err != nil {
+		return "", err
+	}
+	return startValue + "-" + endValue, nil
+}
+
+// ParseMonths parses a DTM value with format code 802 (a number of months)
+func ParseMonths(value string) (int, error) {
+	months, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: '%s' is not a number of months: %w", ErrInvalidValue, value, err)
+	}
+	return months, nil
+}
+
+// FormatMonths formats a number of months as DTM value with format code 802
+func FormatMonths(months int) string {
+	return strconv.Itoa(months)
+}
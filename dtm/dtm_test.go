@@ -0,0 +1,188 @@
+package dtm_test
+
+import (
+	"errors"
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/mako_time_converter"
+	"github.com/hochfrequenz/mako_time_converter/dtm"
+	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type Suite struct {
+	suite.Suite
+	berlin *time.Location
+}
+
+// SetupSuite sets up the tests
+func (s *Suite) SetupSuite() {
+	s.berlin, _ = time.LoadLocation("Europe/Berlin")
+}
+
+func TestInit(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+func pointer[T any](b T) *T {
+	return &b
+}
+
+func (s *Suite) Test_Parse_And_Format() {
+	type testCase struct {
+		value    string
+		format   dtm.FormatCode
+		expected time.Time
+	}
+	testCases := []testCase{
+		{value: "20230101", format: dtm.Format102, expected: time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC)},
+		{value: "20230601", format: dtm.Format102, expected: time.Date(2023, 5, 31, 22, 0, 0, 0, time.UTC)},
+		{value: "202306010600", format: dtm.Format203, expected: time.Date(2023, 6, 1, 4, 0, 0, 0, time.UTC)},
+		{value: "202301010500?+00", format: dtm.Format303, expected: time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range testCases {
+		actual, err := dtm.Parse(tc.value, tc.format, s.berlin)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), actual, is.EqualTo(tc.expected))
+		formatted, err := dtm.Format(actual, tc.format, s.berlin)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), formatted, is.EqualTo(tc.value))
+	}
+}
+
+func (s *Suite) Test_Parse_303_Offsets() {
+	expected := time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC)
+	for _, value := range []string{"202301010500?+00", "202301010500+00", "202301010600?+01", "202301010400-01"} {
+		actual, err := dtm.Parse(value, dtm.Format303, s.berlin)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), actual, is.EqualTo(expected))
+		then.AssertThat(s.T(), actual.Location(), is.EqualTo(time.UTC))
+	}
+	actual, err := dtm.Parse("202301011900+14", dtm.Format303, s.berlin)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), actual, is.EqualTo(expected))
+	for _, value := range []string{"202301010500+99", "202301010500?+15", "202301010500-15"} {
+		_, err = dtm.Parse(value, dtm.Format303, s.berlin)
+		then.AssertThat(s.T(), errors.Is(err, dtm.ErrInvalidValue), is.True())
+	}
+}
+
+func (s *Suite) Test_Parse_Invalid_Values() {
+	invalidValues := map[string]dtm.FormatCode{
+		"2023010":           dtm.Format102,
+		"20230101":          dtm.Format203,
+		"202301010500":      dtm.Format303,
+		"2023010105001+00":  dtm.Format303,
+		"202301010500?000":  dtm.Format303,
+		"202301010500?+0x":  dtm.Format303,
+		"20230101-20230201": dtm.Format719,
+	}
+	for value, format := range invalidValues {
+		_, err := dtm.Parse(value, format, s.berlin)
+		then.AssertThat(s.T(), err, is.Not(is.Nil()))
+	}
+	_, err := dtm.Parse("3", dtm.Format802, s.berlin)
+	then.AssertThat(s.T(), errors.Is(err, dtm.ErrUnsupportedFormat), is.True())
+	_, err = dtm.Format(time.Now(), dtm.Format719, s.berlin)
+	then.AssertThat(s.T(), errors.Is(err, dtm.ErrUnsupportedFormat), is.True())
+}
+
+func (s *Suite) Test_Nil_Location() {
+	for _, format := range []dtm.FormatCode{dtm.Format102, dtm.Format203, dtm.Format303} {
+		_, err := dtm.Parse("202301010500?+00", format, nil)
+		then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrNilLocation), is.True())
+		_, err = dtm.Format(time.Now(), format, nil)
+		then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrNilLocation), is.True())
+	}
+	_, _, err := dtm.ParsePeriod("20230101-20230201", dtm.Format718, nil)
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrNilLocation), is.True())
+	_, err = dtm.FormatPeriod(time.Now(), time.Now(), dtm.Format718, nil)
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrNilLocation), is.True())
+}
+
+func (s *Suite) Test_Parse_And_Format_Periods() {
+	start, end, err := dtm.ParsePeriod("202301010600-202302010600", dtm.Format719, s.berlin)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), start, is.EqualTo(time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), end, is.EqualTo(time.Date(2023, 2, 1, 5, 0, 0, 0, time.UTC)))
+	formatted, err := dtm.FormatPeriod(start, end, dtm.Format719, s.berlin)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), formatted, is.EqualTo("202301010600-202302010600"))
+
+	start, end, err = dtm.ParsePeriod("20230101-20230131", dtm.Format718, s.berlin)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), start, is.EqualTo(time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), end, is.EqualTo(time.Date(2023, 1, 30, 23, 0, 0, 0, time.UTC)))
+
+	_, _, err = dtm.ParsePeriod("202301010600", dtm.Format719, s.berlin)
+	then.AssertThat(s.T(), errors.Is(err, dtm.ErrInvalidValue), is.True())
+	_, _, err = dtm.ParsePeriod("202301010600-202302010600", dtm.Format303, s.berlin)
+	then.AssertThat(s.T(), errors.Is(err, dtm.ErrUnsupportedFormat), is.True())
+}
+
+func (s *Suite) Test_Months() {
+	months, err := dtm.ParseMonths("3")
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), months, is.EqualTo(3))
+	then.AssertThat(s.T(), dtm.FormatMonths(12), is.EqualTo("12"))
+	_, err = dtm.ParseMonths("drei")
+	then.AssertThat(s.T(), errors.Is(err, dtm.ErrInvalidValue), is.True())
+}
+
+func (s *Suite) Test_ParseSegment() {
+	segment, err := dtm.ParseSegment("DTM+163:202301010500?+00:303'")
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), segment, is.EqualTo(dtm.Segment{Qualifier: "163", Value: "202301010500?+00", Format: dtm.Format303}))
+	then.AssertThat(s.T(), segment.String(), is.EqualTo("DTM+163:202301010500?+00:303'"))
+	timestamp, err := segment.Time(s.berlin)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), timestamp, is.EqualTo(time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC)))
+
+	for _, invalidSegment := range []string{"QTY+220:123'", "DTM+163:202301010500?+00'", "DTM+163"} {
+		_, err = dtm.ParseSegment(invalidSegment)
+		then.AssertThat(s.T(), errors.Is(err, dtm.ErrInvalidValue), is.True())
+	}
+}
+
+func (s *Suite) Test_Segment_Configuration() {
+	segment := dtm.Segment{Qualifier: "93", Value: "202302010500?+00", Format: dtm.Format303}
+	configuration, err := segment.Configuration(true)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), configuration, is.EqualTo(mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(true), IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.EXCLUSIVE)}))
+
+	segment = dtm.Segment{Qualifier: "92", Value: "20230101", Format: dtm.Format102}
+	configuration, err = segment.Configuration(true)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), configuration, is.EqualTo(mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(false), StripTime: true}))
+
+	configuration, err = segment.Configuration(false)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), configuration, is.EqualTo(mako_time_converter.DateTimeConfiguration{StripTime: true}))
+
+	_, err = dtm.Segment{Qualifier: "137", Value: "202301010500?+00", Format: dtm.Format303}.Configuration(false)
+	then.AssertThat(s.T(), errors.Is(err, dtm.ErrUnknownQualifier), is.True())
+}
+
+func (s *Suite) Test_Normalise() {
+	converter := mako_time_converter.NewGasTagConverter("Europe/Berlin")
+	inclusiveNonGasTagAwareEnd := mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(false), IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.INCLUSIVE)}
+	segment, err := dtm.ParseSegment("DTM+93:202302010500?+00:303'")
+	then.AssertThat(s.T(), err, is.Nil())
+	actual, err := dtm.Normalise(converter, segment, true, inclusiveNonGasTagAwareEnd)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), actual, is.EqualTo(time.Date(2023, 1, 30, 23, 0, 0, 0, time.UTC))) // 2023-01-31 German local date
+
+	// a date only Vertragsbeginn of gas is converted to the start of the gas day
+	segment, err = dtm.ParseSegment("DTM+92:20230101:102'")
+	then.AssertThat(s.T(), err, is.Nil())
+	actual, err = dtm.Normalise(converter, segment, true, mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(true)})
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), actual, is.EqualTo(time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC)))
+
+	_, err = dtm.Normalise(converter, dtm.Segment{Qualifier: "137", Value: "202301010500?+00", Format: dtm.Format303}, true, inclusiveNonGasTagAwareEnd)
+	then.AssertThat(s.T(), errors.Is(err, dtm.ErrUnknownQualifier), is.True())
+	_, err = dtm.Normalise(converter, dtm.Segment{Qualifier: "93", Value: "2023", Format: dtm.Format303}, true, inclusiveNonGasTagAwareEnd)
+	then.AssertThat(s.T(), errors.Is(err, dtm.ErrInvalidValue), is.True())
+}
@@ -0,0 +1,132 @@
+package dtm
+
+import (
+	"errors"
+	"fmt"
+	"github.com/hochfrequenz/mako_time_converter"
+	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
+	"strings"
+	"time"
+)
+
+// Role describes whether the date of a DTM segment is the start or the end of a period
+type Role int
+
+const (
+	// Start means that the DTM segment contains the start of a period (e.g. Vertragsbeginn)
+	Start Role = iota + 1
+	// End means that the DTM segment contains the (exclusive) end of a period (e.g. Vertragsende)
+	End
+)
+
+// qualifierRoles maps the DTM qualifiers (data element 2005) to the role of their date
+var qualifierRoles = map[string]Role{
+	"92":  Start, // Vertragsbeginn / Beginn zum
+	"93":  End,   // Vertragsende / Ende zum
+	"157": Start, // Gültigkeit, Beginndatum
+	"163": Start, // Beginn Zeitraum / Verarbeitung, Beginndatum
+	"164": End,   // Ende Zeitraum / Verarbeitung, Endedatum
+}
+
+// ErrUnknownQualifier is returned if a DTM qualifier has no known Role
+var ErrUnknownQualifier = errors.New("unknown DTM qualifier")
+
+// RoleOf returns the Role of the given DTM qualifier
+func RoleOf(qualifier string) (Role, error) {
+	role, ok := qualifierRoles[qualifier]
+	if !ok {
+		return 0, fmt.Errorf("%w: '%s'", ErrUnknownQualifier, qualifier)
+	}
+	return role, nil
+}
+
+// Segment is a DTM segment, e.g. DTM+163:202301010500?+00:303'
+type Segment struct {
+	// Qualifier is the "Date or time or period function code qualifier" (data element 2005), e.g. "163"
+	Qualifier string
+	// Value is the (still escaped) date, time or period (data element 2380), e.g. "202301010500?+00"
+	Value string
+	// Format is the format of the Value (data element 2379), e.g. Format303
+	Format FormatCode
+}
+
+// splitComponents splits the composite data element at unescaped component separators (':')
+func splitComponents(composite string) []string {
+	var components []string
+	var builder strings.Builder
+	released := false
+	for _, r := range composite {
+		switch {
+		case released:
+			released = false
+		case r == releaseCharacter:
+			released = true
+		case r == ':':
+			components = append(components, builder.String())
+			builder.Reset()
+			continue
+		}
+		builder.WriteRune(r)
+	}
+	return append(components, builder.String())
+}
+
+// ParseSegment parses a DTM segment like "DTM+163:202301010500?+00:303'". The segment terminator is optional.
+func ParseSegment(segment string) (Segment, error) {
+	composite, found := strings.CutPrefix(segment, "DTM+")
+	if !found {
+		return Segment{}, fmt.Errorf("%w: '%s' is not a DTM segment", ErrInvalidValue, segment)
+	}
+	composite = strings.TrimSuffix(composite, "'")
+	components := splitComponents(composite)
+	if len(components) != 3 {
+		return Segment{}, fmt.Errorf("%w: expected qualifier, value and format in '%s'", ErrInvalidValue, segment)
+	}
+	return Segment{Qualifier: components[0], Value: components[1], Format: FormatCode(components[2])}, nil
+}
+
+// String returns the segment as EDIFACT string including the segment terminator
+func (s Segment) String() string {
+	return fmt.Sprintf("DTM+%s:%s:%s'", s.Qualifier, s.Value, s.Format)
+}
+
+// Configuration returns the DateTimeConfiguration that describes the date of the segment as it is defined in MaKo: ends are exclusive and date times of gas are aware of the German Gas-Tag. Dates without time (Format102) are not Gas-Tag aware and have their time stripped.
+func (s Segment) Configuration(isGas bool) (mako_time_converter.DateTimeConfiguration, error) {
+	role, err := RoleOf(s.Qualifier)
+	if err != nil {
+		return mako_time_converter.DateTimeConfiguration{}, err
+	}
+	isDateOnly := s.Format == Format102
+	configuration := mako_time_converter.DateTimeConfiguration{
+		IsGas:     isGas,
+		IsEndDate: role == End,
+		StripTime: isDateOnly,
+	}
+	if isGas {
+		isGasTagAware := !isDateOnly
+		configuration.IsGasTagAware = &isGasTagAware
+	}
+	if configuration.IsEndDate {
+		exclusive := enddatetimekind.EXCLUSIVE
+		configuration.EndDateTimeKind = &exclusive
+	}
+	return configuration, nil
+}
+
+// Time parses the Value of the segment (see Parse)
+func (s Segment) Time(location *time.Location) (time.Time, error) {
+	return Parse(s.Value, s.Format, location)
+}
+
+// Normalise parses the segment and converts its date from the semantics of the segment (see Segment.Configuration) to the given target configuration
+func Normalise(converter mako_time_converter.GasTagConverter, segment Segment, isGas bool, target mako_time_converter.DateTimeConfiguration) (time.Time, error) {
+	source, err := segment.Configuration(isGas)
+	if err != nil {
+		return time.Time{}, err
+	}
+	timestamp, err := segment.Time(converter.Location())
+	if err != nil {
+		return time.Time{}, err
+	}
+	return converter.Convert(timestamp, mako_time_converter.DateTimeConversionConfiguration{Source: source, Target: target})
+}
@@ -11,4 +11,12 @@ const (
 	INCLUSIVE EndDateTimeKind = iota + 1
 	// EXCLUSIVE means, that the end date shall be understood as exclusive end date; e.g. "2022-11-01" for end of October
 	EXCLUSIVE
+	// INCLUSIVE_LAST_SECOND means, that the end date is the start of the last second before the exclusive end; e.g. "2022-10-31T23:59:59" (German local time) for end of October
+	INCLUSIVE_LAST_SECOND
+	// INCLUSIVE_LAST_MILLISECOND means, that the end date is the start of the last millisecond before the exclusive end; e.g. "2022-10-31T23:59:59.999" (German local time) for end of October
+	INCLUSIVE_LAST_MILLISECOND
+	// INCLUSIVE_LAST_QUARTER_HOUR means, that the end date is the start of the last quarter hour before the exclusive end; e.g. "2022-10-31T23:45" (German local time) for end of October or "2022-11-01T05:45" for the end of the October Gastag
+	INCLUSIVE_LAST_QUARTER_HOUR
+	// INCLUSIVE_LAST_HOUR means, that the end date is the start of the last hour before the exclusive end; e.g. "2022-10-31T23:00" (German local time) for end of October
+	INCLUSIVE_LAST_HOUR
 )
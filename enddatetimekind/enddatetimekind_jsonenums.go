@@ -9,13 +9,21 @@ import (
 
 var (
 	_EndDateTimeKindNameToValue = map[string]EndDateTimeKind{
-		"INCLUSIVE": INCLUSIVE,
-		"EXCLUSIVE": EXCLUSIVE,
+		"INCLUSIVE":                   INCLUSIVE,
+		"EXCLUSIVE":                   EXCLUSIVE,
+		"INCLUSIVE_LAST_SECOND":       INCLUSIVE_LAST_SECOND,
+		"INCLUSIVE_LAST_MILLISECOND":  INCLUSIVE_LAST_MILLISECOND,
+		"INCLUSIVE_LAST_QUARTER_HOUR": INCLUSIVE_LAST_QUARTER_HOUR,
+		"INCLUSIVE_LAST_HOUR":         INCLUSIVE_LAST_HOUR,
 	}
 
 	_EndDateTimeKindValueToName = map[EndDateTimeKind]string{
-		INCLUSIVE: "INCLUSIVE",
-		EXCLUSIVE: "EXCLUSIVE",
+		INCLUSIVE:                   "INCLUSIVE",
+		EXCLUSIVE:                   "EXCLUSIVE",
+		INCLUSIVE_LAST_SECOND:       "INCLUSIVE_LAST_SECOND",
+		INCLUSIVE_LAST_MILLISECOND:  "INCLUSIVE_LAST_MILLISECOND",
+		INCLUSIVE_LAST_QUARTER_HOUR: "INCLUSIVE_LAST_QUARTER_HOUR",
+		INCLUSIVE_LAST_HOUR:         "INCLUSIVE_LAST_HOUR",
 	}
 )
 
@@ -23,8 +31,12 @@ func init() {
 	var v EndDateTimeKind
 	if _, ok := interface{}(v).(fmt.Stringer); ok {
 		_EndDateTimeKindNameToValue = map[string]EndDateTimeKind{
-			interface{}(INCLUSIVE).(fmt.Stringer).String(): INCLUSIVE,
-			interface{}(EXCLUSIVE).(fmt.Stringer).String(): EXCLUSIVE,
+			interface{}(INCLUSIVE).(fmt.Stringer).String():                   INCLUSIVE,
+			interface{}(EXCLUSIVE).(fmt.Stringer).String():                   EXCLUSIVE,
+			interface{}(INCLUSIVE_LAST_SECOND).(fmt.Stringer).String():       INCLUSIVE_LAST_SECOND,
+			interface{}(INCLUSIVE_LAST_MILLISECOND).(fmt.Stringer).String():  INCLUSIVE_LAST_MILLISECOND,
+			interface{}(INCLUSIVE_LAST_QUARTER_HOUR).(fmt.Stringer).String(): INCLUSIVE_LAST_QUARTER_HOUR,
+			interface{}(INCLUSIVE_LAST_HOUR).(fmt.Stringer).String():         INCLUSIVE_LAST_HOUR,
 		}
 	}
 }
@@ -10,11 +10,15 @@ func _() {
 	var x [1]struct{}
 	_ = x[INCLUSIVE-1]
 	_ = x[EXCLUSIVE-2]
+	_ = x[INCLUSIVE_LAST_SECOND-3]
+	_ = x[INCLUSIVE_LAST_MILLISECOND-4]
+	_ = x[INCLUSIVE_LAST_QUARTER_HOUR-5]
+	_ = x[INCLUSIVE_LAST_HOUR-6]
 }
 
-const _EndDateTimeKind_name = "INCLUSIVEEXCLUSIVE"
+const _EndDateTimeKind_name = "INCLUSIVEEXCLUSIVEINCLUSIVE_LAST_SECONDINCLUSIVE_LAST_MILLISECONDINCLUSIVE_LAST_QUARTER_HOURINCLUSIVE_LAST_HOUR"
 
-var _EndDateTimeKind_index = [...]uint8{0, 9, 18}
+var _EndDateTimeKind_index = [...]uint8{0, 9, 18, 39, 65, 92, 111}
 
 func (i EndDateTimeKind) String() string {
 	i -= 1
@@ -0,0 +1,136 @@
+package mako_time_converter
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"strings"
+	"time"
+)
+
+// ErrTimezoneDataMissing is returned if the timezone data for a location could not be loaded (e.g. because tzdata are not installed on the system)
+var ErrTimezoneDataMissing = errors.New("timezone data missing")
+
+// ErrNilLocation is returned if a nil *time.Location is used to create a GasTagConverter
+var ErrNilLocation = errors.New("the location must not be nil")
+
+// ErrNotGerman6Am is the sentinel for all NotGerman6AmErrors; use it with errors.Is
+var ErrNotGerman6Am = errors.New("not German 6am")
+
+// ErrNotGermanMidnight is the sentinel for all NotGermanMidnightErrors; use it with errors.Is
+var ErrNotGermanMidnight = errors.New("not German midnight")
+
+// ErrInvalidConfiguration is the sentinel for all InvalidConfigurationErrors; use it with errors.Is
+var ErrInvalidConfiguration = errors.New("invalid configuration")
+
+// ErrInvalidInterval is the sentinel for all InvalidIntervalErrors; use it with errors.Is
+var ErrInvalidInterval = errors.New("invalid interval")
+
+// ErrInvalidResolution is returned if an interval cannot be divided into slots of a given resolution
+var ErrInvalidResolution = errors.New("invalid resolution")
+
+// ErrOutsideInterval is returned if a timestamp is not within an interval
+var ErrOutsideInterval = errors.New("timestamp outside interval")
+
+// ErrUnknownProfile is returned if there is no profile with a given name in a ProfileRegistry
+var ErrUnknownProfile = errors.New("unknown profile")
+
+// ErrInvalidMarketDefinition is returned if a MarketDefinition is invalid (e.g. the gas day start hour is not between 0 and 23)
+var ErrInvalidMarketDefinition = errors.New("invalid market definition")
+
+// ErrIncompleteProfile is returned if a ConvertedTime or Converted is read or written without a GasTagConverter in its ConversionProfile
+var ErrIncompleteProfile = errors.New("the conversion profile has no converter")
+
+// ErrUnsupportedDatabaseValue is returned if a database value cannot be scanned into a ConvertedTime
+var ErrUnsupportedDatabaseValue = errors.New("unsupported database value")
+
+// ErrUnknownSparte is returned if a sparte.Sparte is not one of the defined Sparten
+var ErrUnknownSparte = errors.New("unknown Sparte")
+
+// NotGerman6AmError is returned if a timestamp was expected to be German 6am (the start of a German Gastag) but is not
+type NotGerman6AmError struct {
+	// Timestamp is the timestamp as it was given
+	Timestamp time.Time
+	// LocalTime is the Timestamp in German local time
+	LocalTime time.Time
+}
+
+func (e NotGerman6AmError) Error() string {
+	return fmt.Sprintf("the given time %v is not German 6am but %v", e.Timestamp, e.LocalTime)
+}
+
+// Is allows to use errors.Is(err, ErrNotGerman6Am)
+func (e NotGerman6AmError) Is(target error) bool {
+	return target == ErrNotGerman6Am
+}
+
+// NotGermanMidnightError is returned if a timestamp was expected to be German midnight (the start of a German Stromtag) but is not
+type NotGermanMidnightError struct {
+	// Timestamp is the timestamp as it was given
+	Timestamp time.Time
+	// LocalTime is the Timestamp in German local time
+	LocalTime time.Time
+}
+
+func (e NotGermanMidnightError) Error() string {
+	return fmt.Sprintf("the given time %v is not German midnight but %v", e.Timestamp, e.LocalTime)
+}
+
+// Is allows to use errors.Is(err, ErrNotGermanMidnight)
+func (e NotGermanMidnightError) Is(target error) bool {
+	return target == ErrNotGermanMidnight
+}
+
+// InvalidConfigurationError is returned if a DateTimeConversionConfiguration is invalid
+type InvalidConfigurationError struct {
+	// Field is the (first) field that violates a Rule, e.g. "Source.IsGasTagAware"
+	Field string
+	// Rule is the (first) rule that is violated, e.g. "required_if"
+	Rule string
+	// Err is the underlying error of the validation (usually validator.ValidationErrors which contain all violations)
+	Err error
+}
+
+func (e InvalidConfigurationError) Error() string {
+	return fmt.Sprintf("the configuration is invalid: field '%s' violates rule '%s': %v", e.Field, e.Rule, e.Err)
+}
+
+// Is allows to use errors.Is(err, ErrInvalidConfiguration)
+func (e InvalidConfigurationError) Is(target error) bool {
+	return target == ErrInvalidConfiguration
+}
+
+// Unwrap returns the underlying validation error
+func (e InvalidConfigurationError) Unwrap() error {
+	return e.Err
+}
+
+// newInvalidConfigurationError converts the error returned by the validator into an InvalidConfigurationError
+func newInvalidConfigurationError(err error) InvalidConfigurationError {
+	result := InvalidConfigurationError{Err: err}
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) && len(validationErrors) > 0 {
+		namespace := validationErrors[0].Namespace()
+		// the namespace starts with the name of the validated struct type, e.g. "DateTimeConversionConfiguration.Source.IsGasTagAware"
+		if _, field, found := strings.Cut(namespace, "."); found {
+			namespace = field
+		}
+		result.Field = namespace
+		result.Rule = validationErrors[0].Tag()
+	}
+	return result
+}
+
+// InvalidIntervalError is returned if the End of an Interval is before its Start
+type InvalidIntervalError struct {
+	Interval Interval
+}
+
+func (e InvalidIntervalError) Error() string {
+	return fmt.Sprintf("the end %v of the interval must not be before its start %v", e.Interval.End, e.Interval.Start)
+}
+
+// Is allows to use errors.Is(err, ErrInvalidInterval)
+func (e InvalidIntervalError) Is(target error) bool {
+	return target == ErrInvalidInterval
+}
@@ -0,0 +1,70 @@
+package mako_time_converter_test
+
+import (
+	"errors"
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/go-playground/validator/v10"
+	"github.com/hochfrequenz/mako_time_converter"
+	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
+	"time"
+)
+
+func (s *Suite) Test_NotGerman6AmError() {
+	not6am := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err := getBerlinConverter().Convert6AamToMidnight(not6am)
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrNotGerman6Am), is.True())
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrNotGermanMidnight), is.False())
+	var not6AmError mako_time_converter.NotGerman6AmError
+	then.AssertThat(s.T(), errors.As(err, &not6AmError), is.True())
+	then.AssertThat(s.T(), not6AmError.Timestamp, is.EqualTo(not6am))
+	then.AssertThat(s.T(), not6AmError.LocalTime.Hour(), is.EqualTo(1))
+}
+
+func (s *Suite) Test_NotGermanMidnightError() {
+	notMidnight := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err := getBerlinConverter().ConvertMidnightTo6Am(notMidnight)
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrNotGermanMidnight), is.True())
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrNotGerman6Am), is.False())
+	var notMidnightError mako_time_converter.NotGermanMidnightError
+	then.AssertThat(s.T(), errors.As(err, &notMidnightError), is.True())
+	then.AssertThat(s.T(), notMidnightError.Timestamp, is.EqualTo(notMidnight))
+	then.AssertThat(s.T(), notMidnightError.LocalTime.Hour(), is.EqualTo(1))
+}
+
+func (s *Suite) Test_InvalidConfigurationError() {
+	invalidConfigs := map[string]mako_time_converter.DateTimeConversionConfiguration{
+		"Source.EndDateTimeKind": {
+			Source: mako_time_converter.DateTimeConfiguration{IsEndDate: true}, // no enddatetime kind given
+			Target: mako_time_converter.DateTimeConfiguration{IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.EXCLUSIVE)},
+		},
+		"Target.IsGasTagAware": {
+			Source: mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(true)},
+			Target: mako_time_converter.DateTimeConfiguration{IsGas: true}, // no gastag awareness given
+		},
+		"Source/Target.IsGas": {
+			Source: mako_time_converter.DateTimeConfiguration{IsGas: false},
+			Target: mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(true)},
+		},
+	}
+	converter := getBerlinConverter()
+	for expectedField, invalidConfig := range invalidConfigs {
+		_, err := converter.Convert(time.Time{}, invalidConfig)
+		then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+		var configurationError mako_time_converter.InvalidConfigurationError
+		then.AssertThat(s.T(), errors.As(err, &configurationError), is.True())
+		then.AssertThat(s.T(), configurationError.Field, is.EqualTo(expectedField))
+		then.AssertThat(s.T(), configurationError.Rule, is.Not(is.EqualTo("")))
+		var validationErrors validator.ValidationErrors
+		then.AssertThat(s.T(), errors.As(err, &validationErrors), is.True()) // the original error is still accessible
+	}
+}
+
+func (s *Suite) Test_InvalidIntervalError() {
+	invalidInterval := mako_time_converter.Interval{Start: time.Date(2023, 1, 2, 23, 0, 0, 0, time.UTC), End: time.Date(2023, 1, 1, 23, 0, 0, 0, time.UTC)}
+	_, err := getBerlinConverter().ConvertInterval(invalidInterval, mako_time_converter.DateTimeConversionConfiguration{})
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidInterval), is.True())
+	var intervalError mako_time_converter.InvalidIntervalError
+	then.AssertThat(s.T(), errors.As(err, &intervalError), is.True())
+	then.AssertThat(s.T(), intervalError.Interval, is.EqualTo(invalidInterval))
+}
@@ -0,0 +1,102 @@
+package fristen
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// A Feiertag is a day that is not a Werktag in the sense of the BDEW, although it might be a weekday
+type Feiertag struct {
+	// Name is the German name of the day, e.g. "Ostermontag"
+	Name string
+	// Date is the date of the day (midnight UTC); compare it with the German local date of a timestamp, not with the timestamp itself
+	Date time.Time
+}
+
+// Easter returns the date (midnight UTC) of Easter Sunday in the given year of the Gregorian calendar.
+// It uses the anonymous Gregorian algorithm (Meeus/Jones/Butcher), so no external service or table is required.
+func Easter(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return date(year, time.Month(month), day)
+}
+
+// Feiertage returns all days of the given year that are no Werktage according to the BDEW although they are not on a weekend (or might be on a weekday in other years), sorted by date.
+// These are the nationwide public holidays in Germany (including the Reformationstag 2017, which was a nationwide holiday once) and the 24th and 31st of December.
+// Holidays of single federal states are not included, because they do not affect the Fristen of the Marktkommunikation.
+func Feiertage(year int) []Feiertag {
+	easter := Easter(year)
+	result := []Feiertag{
+		{Name: "Neujahr", Date: date(year, time.January, 1)},
+		{Name: "Karfreitag", Date: easter.AddDate(0, 0, -2)},
+		{Name: "Ostermontag", Date: easter.AddDate(0, 0, 1)},
+		{Name: "Tag der Arbeit", Date: date(year, time.May, 1)},
+		{Name: "Christi Himmelfahrt", Date: easter.AddDate(0, 0, 39)},
+		{Name: "Pfingstmontag", Date: easter.AddDate(0, 0, 50)},
+		{Name: "Tag der Deutschen Einheit", Date: date(year, time.October, 3)},
+		{Name: "Heiligabend", Date: date(year, time.December, 24)},
+		{Name: "1. Weihnachtsfeiertag", Date: date(year, time.December, 25)},
+		{Name: "2. Weihnachtsfeiertag", Date: date(year, time.December, 26)},
+		{Name: "Silvester", Date: date(year, time.December, 31)},
+	}
+	if year == 2017 {
+		result = append(result, Feiertag{Name: "Reformationstag", Date: date(year, time.October, 31)})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Date.Before(result[j].Date)
+	})
+	return result
+}
+
+// monthDay is a day of a year
+type monthDay struct {
+	month time.Month
+	day   int
+}
+
+// feiertageByYear caches the Feiertage per year (int → map[monthDay]bool), so that they are not computed again for every day that is checked. The maps are not modified after they have been stored.
+var feiertageByYear sync.Map
+
+// feiertageOf returns the days of the Feiertage of the given year
+func feiertageOf(year int) map[monthDay]bool {
+	if cached, found := feiertageByYear.Load(year); found {
+		return cached.(map[monthDay]bool)
+	}
+	result := map[monthDay]bool{}
+	for _, feiertag := range Feiertage(year) {
+		result[monthDay{month: feiertag.Date.Month(), day: feiertag.Date.Day()}] = true
+	}
+	actual, _ := feiertageByYear.LoadOrStore(year, result)
+	return actual.(map[monthDay]bool)
+}
+
+// isFeiertag returns true if the date (year, month, day) is one of the Feiertage of its year
+func isFeiertag(year int, month time.Month, day int) bool {
+	return feiertageOf(year)[monthDay{month: month, day: day}]
+}
+
+// isWerktag returns true if the date is a Werktag according to the BDEW: Monday to Friday, but no Feiertag
+func isWerktag(year int, month time.Month, day int) bool {
+	switch date(year, month, day).Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+	return !isFeiertag(year, month, day)
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
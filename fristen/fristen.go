@@ -0,0 +1,128 @@
+// Package fristen calculates the deadlines (Fristen) of the German Marktkommunikation (e.g. GPKE, GeLi Gas) which are measured in Werktage.
+// A Werktag is defined by the BDEW as Monday to Friday, except for the nationwide public holidays and the 24th and 31st of December (see Feiertage).
+// The holidays are computed (Easter based), so the package works offline.
+//
+// All calculations are based on days of the respective Sparte: Gas Fristen are measured in Gastage (from German 6am to German 6am), all other Fristen in calendar days (Stromtage, from German midnight to German midnight).
+// A Gastag is a Werktag iff the calendar day on which it starts is a Werktag.
+package fristen
+
+import (
+	"fmt"
+	"github.com/hochfrequenz/mako_time_converter"
+	"github.com/hochfrequenz/mako_time_converter/sparte"
+	"time"
+)
+
+// A Calendar calculates Fristen in Strom-day or Gas-day semantics. Create it with NewStromCalendar, NewGasCalendar or NewCalendar.
+type Calendar struct {
+	converter  mako_time_converter.GasTagConverter
+	boundaries mako_time_converter.Boundaries
+	// gas is true if the days of the calendar are Gastage
+	gas bool
+}
+
+// NewStromCalendar returns a Calendar that measures Fristen in Stromtage (German midnight to German midnight)
+func NewStromCalendar(converter mako_time_converter.GasTagConverter) Calendar {
+	return Calendar{converter: converter, boundaries: mako_time_converter.NewBoundaries(converter)}
+}
+
+// NewGasCalendar returns a Calendar that measures Fristen in Gastage (German 6am to German 6am)
+func NewGasCalendar(converter mako_time_converter.GasTagConverter) Calendar {
+	return Calendar{converter: converter, boundaries: mako_time_converter.NewBoundaries(converter), gas: true}
+}
+
+// NewCalendar returns the Calendar for the given Sparte: Gastage for sparte.GAS, calendar days for all other Sparten. It returns an error that wraps mako_time_converter.ErrUnknownSparte if the Sparte is unknown.
+func NewCalendar(converter mako_time_converter.GasTagConverter, s sparte.Sparte) (Calendar, error) {
+	if !s.IsValid() {
+		return Calendar{}, fmt.Errorf("%w: %d", mako_time_converter.ErrUnknownSparte, s)
+	}
+	if s == sparte.GAS {
+		return NewGasCalendar(converter), nil
+	}
+	return NewStromCalendar(converter), nil
+}
+
+// Day returns the day (Gastag or Stromtag) to which the timestamp belongs
+func (c Calendar) Day(timestamp time.Time) mako_time_converter.Interval {
+	if c.gas {
+		return c.boundaries.GasDay(timestamp)
+	}
+	return c.boundaries.StromDay(timestamp)
+}
+
+// dayStart returns the start of the day to which the timestamp belongs
+func (c Calendar) dayStart(timestamp time.Time) time.Time {
+	return c.Day(timestamp).Start
+}
+
+// nextDay returns the start of the day after the day that starts at dayStart
+func (c Calendar) nextDay(dayStart time.Time) time.Time {
+	return c.Day(dayStart).End
+}
+
+// previousDay returns the start of the day before the day that starts at dayStart
+func (c Calendar) previousDay(dayStart time.Time) time.Time {
+	return c.dayStart(dayStart.Add(-time.Nanosecond))
+}
+
+// IsWerktag returns true if the day to which the timestamp belongs is a Werktag
+func (c Calendar) IsWerktag(timestamp time.Time) bool {
+	year, month, day := c.dayStart(timestamp).In(c.converter.Location()).Date()
+	return isWerktag(year, month, day)
+}
+
+// AddWerktage returns the start of the n-th Werktag after (n > 0) or before (n < 0) the day to which the timestamp belongs; the day of the timestamp itself is not counted.
+// For n == 0 it returns the start of the day to which the timestamp belongs (even if it is not a Werktag).
+// E.g. one Werktag after Friday, 2023-12-22 (or after the weekend and Christmas that follow it) is Wednesday, 2023-12-27.
+func (c Calendar) AddWerktage(timestamp time.Time, n int) time.Time {
+	result := c.dayStart(timestamp)
+	for n > 0 {
+		result = c.nextDay(result)
+		if c.IsWerktag(result) {
+			n--
+		}
+	}
+	for n < 0 {
+		result = c.previousDay(result)
+		if c.IsWerktag(result) {
+			n++
+		}
+	}
+	return result
+}
+
+// WerktageBetween returns the number of Werktage after the day to which a belongs up to and including the day to which b belongs. It is negative if b belongs to a day before the day of a (WerktageBetween(a, b) == -WerktageBetween(b, a)).
+// If a belongs to a Werktag, WerktageBetween(a, AddWerktage(a, n)) is n.
+func (c Calendar) WerktageBetween(a, b time.Time) int {
+	start, end := c.dayStart(a), c.dayStart(b)
+	sign := 1
+	if end.Before(start) {
+		start, end, sign = end, start, -1
+	}
+	result := 0
+	for day := c.nextDay(start); !day.After(end); day = c.nextDay(day) {
+		if c.IsWerktag(day) {
+			result++
+		}
+	}
+	return sign * result
+}
+
+// LatestAnswer returns the (exclusive) deadline for an answer that is due within n Werktage after the receipt of a message: the end of the n-th Werktag after the day of receipt (the day of receipt itself is not counted).
+// Deadlines in hours (e.g. "within 24 hours") do not depend on Werktage; use received.Add(24 * time.Hour) instead.
+func (c Calendar) LatestAnswer(received time.Time, n int) time.Time {
+	return c.nextDay(c.AddWerktage(received, n))
+}
+
+// LatestAnnouncement returns the (exclusive) deadline for the receipt of a message that has to be sent n Werktage before the given start (e.g. a Lieferbeginn).
+// There are at least n full Werktage between the day of receipt and the day of the start (neither of which is counted) iff the message is received before the returned timestamp.
+func (c Calendar) LatestAnnouncement(start time.Time, n int) time.Time {
+	result := c.dayStart(start)
+	for ; n > 0; n-- {
+		result = c.previousDay(result)
+		for !c.IsWerktag(result) {
+			result = c.previousDay(result)
+		}
+	}
+	return result
+}
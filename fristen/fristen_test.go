@@ -0,0 +1,135 @@
+package fristen_test
+
+import (
+	"errors"
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/mako_time_converter"
+	"github.com/hochfrequenz/mako_time_converter/fristen"
+	"github.com/hochfrequenz/mako_time_converter/sparte"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type Suite struct {
+	suite.Suite
+	berlin *time.Location
+	strom  fristen.Calendar
+	gas    fristen.Calendar
+}
+
+// SetupSuite sets up the tests
+func (s *Suite) SetupSuite() {
+	converter := mako_time_converter.NewGasTagConverter("Europe/Berlin")
+	s.berlin = converter.Location()
+	s.strom = fristen.NewStromCalendar(converter)
+	s.gas = fristen.NewGasCalendar(converter)
+}
+
+func TestInit(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+// german returns the given German local time
+func (s *Suite) german(year int, month time.Month, day, hour int) time.Time {
+	return time.Date(year, month, day, hour, 0, 0, 0, s.berlin)
+}
+
+func (s *Suite) Test_Easter() {
+	for year, expected := range map[int]time.Time{
+		1818: time.Date(1818, time.March, 22, 0, 0, 0, 0, time.UTC),
+		1943: time.Date(1943, time.April, 25, 0, 0, 0, 0, time.UTC),
+		2000: time.Date(2000, time.April, 23, 0, 0, 0, 0, time.UTC),
+		2019: time.Date(2019, time.April, 21, 0, 0, 0, 0, time.UTC),
+		2023: time.Date(2023, time.April, 9, 0, 0, 0, 0, time.UTC),
+		2024: time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC),
+		2025: time.Date(2025, time.April, 20, 0, 0, 0, 0, time.UTC),
+	} {
+		then.AssertThat(s.T(), fristen.Easter(year), is.EqualTo(expected))
+	}
+}
+
+func (s *Suite) Test_Feiertage() {
+	feiertage := fristen.Feiertage(2023)
+	then.AssertThat(s.T(), len(feiertage), is.EqualTo(11))
+	then.AssertThat(s.T(), feiertage[1].Name, is.EqualTo("Karfreitag"))
+	then.AssertThat(s.T(), feiertage[1].Date, is.EqualTo(time.Date(2023, time.April, 7, 0, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), feiertage[5].Name, is.EqualTo("Pfingstmontag"))
+	then.AssertThat(s.T(), feiertage[5].Date, is.EqualTo(time.Date(2023, time.May, 29, 0, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), feiertage[10].Name, is.EqualTo("Silvester"))
+	then.AssertThat(s.T(), len(fristen.Feiertage(2017)), is.EqualTo(12)) // Reformationstag
+	then.AssertThat(s.T(), s.strom.IsWerktag(s.german(2017, time.October, 31, 12)), is.False())
+	then.AssertThat(s.T(), s.strom.IsWerktag(s.german(2018, time.October, 31, 12)), is.True())
+}
+
+func (s *Suite) Test_IsWerktag() {
+	then.AssertThat(s.T(), s.strom.IsWerktag(s.german(2023, time.December, 22, 12)), is.True())  // Friday
+	then.AssertThat(s.T(), s.strom.IsWerktag(s.german(2023, time.December, 23, 12)), is.False()) // Saturday
+	then.AssertThat(s.T(), s.strom.IsWerktag(s.german(2024, time.December, 24, 12)), is.False()) // Tuesday, Heiligabend
+	then.AssertThat(s.T(), s.strom.IsWerktag(s.german(2024, time.December, 31, 12)), is.False()) // Tuesday, Silvester
+	then.AssertThat(s.T(), s.strom.IsWerktag(s.german(2023, time.May, 18, 12)), is.False())      // Christi Himmelfahrt
+	// 03:00 on Tuesday after Easter still belongs to the Gastag Ostermontag
+	tuesdayNight := s.german(2023, time.April, 11, 3)
+	then.AssertThat(s.T(), s.strom.IsWerktag(tuesdayNight), is.True())
+	then.AssertThat(s.T(), s.gas.IsWerktag(tuesdayNight), is.False())
+	then.AssertThat(s.T(), s.gas.IsWerktag(s.german(2023, time.April, 11, 6)), is.True())
+}
+
+func (s *Suite) Test_AddWerktage() {
+	friday := s.german(2023, time.December, 22, 15)
+	then.AssertThat(s.T(), s.strom.AddWerktage(friday, 0), is.EqualTo(s.german(2023, time.December, 22, 0).UTC()))
+	then.AssertThat(s.T(), s.strom.AddWerktage(friday, 1), is.EqualTo(s.german(2023, time.December, 27, 0).UTC()))
+	then.AssertThat(s.T(), s.strom.AddWerktage(friday, 4), is.EqualTo(s.german(2024, time.January, 2, 0).UTC()))
+	then.AssertThat(s.T(), s.strom.AddWerktage(s.german(2024, time.January, 2, 0), -4), is.EqualTo(s.german(2023, time.December, 22, 0).UTC()))
+	then.AssertThat(s.T(), s.gas.AddWerktage(friday, 1), is.EqualTo(s.german(2023, time.December, 27, 6).UTC()))
+	// the Gastag of 2023-12-23 03:00 is Friday
+	then.AssertThat(s.T(), s.gas.AddWerktage(s.german(2023, time.December, 23, 3), 1), is.EqualTo(s.german(2023, time.December, 27, 6).UTC()))
+	// DST: the day after the transition still starts at midnight/6am local time
+	then.AssertThat(s.T(), s.strom.AddWerktage(s.german(2024, time.March, 29, 12), 1), is.EqualTo(s.german(2024, time.April, 2, 0).UTC()))
+	then.AssertThat(s.T(), s.gas.AddWerktage(s.german(2023, time.October, 27, 12), 1), is.EqualTo(s.german(2023, time.October, 30, 6).UTC()))
+}
+
+func (s *Suite) Test_WerktageBetween() {
+	friday := s.german(2023, time.December, 22, 15)
+	then.AssertThat(s.T(), s.strom.WerktageBetween(friday, s.german(2024, time.January, 2, 8)), is.EqualTo(4))
+	then.AssertThat(s.T(), s.strom.WerktageBetween(s.german(2024, time.January, 2, 8), friday), is.EqualTo(-4))
+	then.AssertThat(s.T(), s.strom.WerktageBetween(friday, friday), is.EqualTo(0))
+	for _, calendar := range []fristen.Calendar{s.strom, s.gas} {
+		start := s.german(2023, time.January, 2, 12)
+		for n := -300; n <= 300; n += 7 {
+			then.AssertThat(s.T(), calendar.WerktageBetween(start, calendar.AddWerktage(start, n)), is.EqualTo(n))
+		}
+	}
+}
+
+func (s *Suite) Test_LatestAnswer() {
+	// received on Friday afternoon, the answer is due at the end of the next Werktag (Wednesday after Christmas)
+	received := s.german(2023, time.December, 22, 15)
+	then.AssertThat(s.T(), s.strom.LatestAnswer(received, 1), is.EqualTo(s.german(2023, time.December, 28, 0).UTC()))
+	then.AssertThat(s.T(), s.gas.LatestAnswer(received, 1), is.EqualTo(s.german(2023, time.December, 28, 6).UTC()))
+}
+
+func (s *Suite) Test_LatestAnnouncement() {
+	lieferbeginn := s.german(2023, time.March, 6, 0) // Monday
+	// Friday must be a full Werktag between receipt and Lieferbeginn, so the message has to be received on Thursday
+	then.AssertThat(s.T(), s.strom.LatestAnnouncement(lieferbeginn, 1), is.EqualTo(s.german(2023, time.March, 3, 0).UTC()))
+	then.AssertThat(s.T(), s.strom.LatestAnnouncement(lieferbeginn, 10), is.EqualTo(s.german(2023, time.February, 20, 0).UTC()))
+	then.AssertThat(s.T(), s.gas.LatestAnnouncement(s.german(2023, time.March, 6, 6), 1), is.EqualTo(s.german(2023, time.March, 3, 6).UTC()))
+	deadline := s.strom.LatestAnnouncement(lieferbeginn, 10)
+	lastReceipt := deadline.Add(-time.Second)
+	then.AssertThat(s.T(), s.strom.WerktageBetween(lastReceipt, lieferbeginn)-1, is.EqualTo(10)) // minus the Monday of the Lieferbeginn
+}
+
+func (s *Suite) Test_NewCalendar() {
+	converter := mako_time_converter.NewGasTagConverter("Europe/Berlin")
+	tuesdayNight := s.german(2023, time.April, 11, 3)
+	gas, err := fristen.NewCalendar(converter, sparte.GAS)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), gas.IsWerktag(tuesdayNight), is.False())
+	wasser, err := fristen.NewCalendar(converter, sparte.WASSER)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), wasser.IsWerktag(tuesdayNight), is.True())
+	_, err = fristen.NewCalendar(converter, sparte.Sparte(0))
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrUnknownSparte), is.True())
+}
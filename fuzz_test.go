@@ -0,0 +1,116 @@
+package mako_time_converter_test
+
+import (
+	"errors"
+	"github.com/hochfrequenz/mako_time_converter"
+	"testing"
+	"time"
+)
+
+// fuzzTimestamp maps arbitrary fuzz input to a timestamp between 1900 and 2200 (the range for which time zone data is meaningful)
+func fuzzTimestamp(seconds int64, nanos uint32) time.Time {
+	const start = -2208988800 // 1900-01-01T00:00:00Z
+	const span = 9467107200   // 300 years
+	seconds %= span
+	if seconds < 0 {
+		seconds += span
+	}
+	return time.Unix(start+seconds, int64(nanos%uint32(time.Second)))
+}
+
+func addFuzzSeeds(f *testing.F) {
+	for _, seed := range []time.Time{
+		time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC),
+		time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC),
+		time.Date(2023, 3, 26, 1, 30, 0, 0, time.UTC),
+		time.Date(2023, 10, 29, 0, 59, 59, 999999999, time.UTC),
+	} {
+		f.Add(seed.Unix()+2208988800, uint32(seed.Nanosecond()), uint8(0))
+	}
+}
+
+func FuzzConvert(f *testing.F) {
+	addFuzzSeeds(f)
+	converter := getBerlinConverter()
+	configurations := allConversionConfigurations()
+	f.Fuzz(func(t *testing.T, seconds int64, nanos uint32, configurationIndex uint8) {
+		timestamp := fuzzTimestamp(seconds, nanos)
+		configuration := configurations[int(configurationIndex)%len(configurations)]
+		result, err := converter.Convert(timestamp, configuration)
+		if err != nil {
+			t.Fatalf("unexpected error for %v and %+v: %v", timestamp, configuration, err)
+		}
+		if result.Location() != time.UTC {
+			t.Fatalf("result %v is not UTC", result)
+		}
+		if configuration.Target.StripTime && !converter.IsGermanMidnight(result) {
+			t.Fatalf("result %v of a configuration with Target.StripTime is not German midnight", result)
+		}
+		if configuration.Source == configuration.Target && !configuration.Source.StripTime && !result.Equal(timestamp) {
+			t.Fatalf("conversion between equal configurations changed %v to %v", timestamp, result)
+		}
+	})
+}
+
+func FuzzStripTime(f *testing.F) {
+	addFuzzSeeds(f)
+	converter := getBerlinConverter()
+	f.Fuzz(func(t *testing.T, seconds int64, nanos uint32, _ uint8) {
+		timestamp := fuzzTimestamp(seconds, nanos)
+		result := converter.StripTime(timestamp)
+		if !converter.IsGermanMidnight(result) || result.Location() != time.UTC {
+			t.Fatalf("StripTime(%v) = %v is not German midnight in UTC", timestamp, result)
+		}
+		if converter.StripTime(result) != result {
+			t.Fatalf("StripTime is not idempotent for %v", timestamp)
+		}
+		if result.After(timestamp) || timestamp.In(converter.Location()).Format(time.DateOnly) != result.In(converter.Location()).Format(time.DateOnly) {
+			t.Fatalf("StripTime(%v) = %v is not the start of the same German day", timestamp, result)
+		}
+	})
+}
+
+func FuzzConvertMidnightTo6Am(f *testing.F) {
+	addFuzzSeeds(f)
+	converter := getBerlinConverter()
+	f.Fuzz(func(t *testing.T, seconds int64, nanos uint32, _ uint8) {
+		timestamp := fuzzTimestamp(seconds, nanos)
+		result, err := converter.ConvertMidnightTo6Am(timestamp)
+		if !converter.IsGermanMidnight(timestamp) {
+			if !errors.Is(err, mako_time_converter.ErrNotGermanMidnight) {
+				t.Fatalf("expected ErrNotGermanMidnight for %v but got %v", timestamp, err)
+			}
+			return
+		}
+		if err != nil || !converter.IsGerman6Am(result) || result.Location() != time.UTC {
+			t.Fatalf("ConvertMidnightTo6Am(%v) = %v, %v", timestamp, result, err)
+		}
+		// IsGermanMidnight ignores fractions of a second, ConvertMidnightTo6Am drops them
+		if converter.StripTime(result) != timestamp.UTC().Truncate(time.Second) {
+			t.Fatalf("ConvertMidnightTo6Am(%v) = %v is not on the same German day", timestamp, result)
+		}
+	})
+}
+
+func FuzzConvert6AamToMidnight(f *testing.F) {
+	addFuzzSeeds(f)
+	converter := getBerlinConverter()
+	f.Fuzz(func(t *testing.T, seconds int64, nanos uint32, _ uint8) {
+		timestamp := fuzzTimestamp(seconds, nanos)
+		result, err := converter.Convert6AamToMidnight(timestamp)
+		if !converter.IsGerman6Am(timestamp) {
+			if !errors.Is(err, mako_time_converter.ErrNotGerman6Am) {
+				t.Fatalf("expected ErrNotGerman6Am for %v but got %v", timestamp, err)
+			}
+			return
+		}
+		if err != nil || !converter.IsGermanMidnight(result) || result.Location() != time.UTC {
+			t.Fatalf("Convert6AamToMidnight(%v) = %v, %v", timestamp, result, err)
+		}
+		back, err := converter.ConvertMidnightTo6Am(result)
+		// IsGerman6Am ignores fractions of a second, Convert6AamToMidnight drops them
+		if err != nil || back != timestamp.UTC().Truncate(time.Second) {
+			t.Fatalf("ConvertMidnightTo6Am(Convert6AamToMidnight(%v)) = %v, %v", timestamp, back, err)
+		}
+	})
+}
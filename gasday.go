@@ -0,0 +1,88 @@
+package mako_time_converter
+
+import "time"
+
+// Boundaries calculates the boundaries (starts and exclusive ends) of German Stromtage and Gastage (and longer periods) in the location and market of a GasTagConverter.
+// The zero value is not usable; always use NewBoundaries to create Boundaries.
+type Boundaries struct {
+	converter locationBasedGasTagConverter
+}
+
+// NewBoundaries returns the Boundaries in the Location and Market of the given converter
+func NewBoundaries(converter GasTagConverter) Boundaries {
+	return Boundaries{converter: locationBased(converter)}
+}
+
+// locationBased returns the implementation of the given converter. Converters that are implemented outside this package (e.g. mocks) are replaced by an implementation with the same Location and Market.
+func locationBased(converter GasTagConverter) locationBasedGasTagConverter {
+	if l, ok := converter.(locationBasedGasTagConverter); ok {
+		return l
+	}
+	return newLocationBasedGasTagConverter(converter.Location(), converter.Market())
+}
+
+// gasDayStartOn returns the start of the German Gastag that starts on the given German local date
+func (l locationBasedGasTagConverter) gasDayStartOn(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, l.market.GasDayStartHour, l.market.GasDayStartMinute, 0, 0, l.gasDayLocation).UTC()
+}
+
+// gasDayStart returns the start of the German Gastag to which the timestamp belongs
+func (l locationBasedGasTagConverter) gasDayStart(timestamp time.Time) time.Time {
+	year, month, day := timestamp.In(l.gasDayLocation).Date()
+	start := l.gasDayStartOn(year, month, day)
+	if timestamp.Before(start) {
+		// e.g. 03:00 German local time still belongs to the Gastag that started on the previous day
+		start = l.gasDayStartOn(year, month, day-1)
+	}
+	return start
+}
+
+// GasDayStart returns the start (German 6am) of the German Gastag to which the given timestamp belongs. E.g. 03:00 German local time belongs to the Gastag which started at 06:00 of the previous day.
+func (b Boundaries) GasDayStart(timestamp time.Time) time.Time {
+	return b.converter.gasDayStart(timestamp)
+}
+
+// GasDayEnd returns the exclusive end (German 6am of the following day) of the German Gastag to which the given timestamp belongs
+func (b Boundaries) GasDayEnd(timestamp time.Time) time.Time {
+	return b.converter.addGasDays(b.converter.gasDayStart(timestamp), 1)
+}
+
+// StromDayStart returns the start (German midnight) of the German Stromtag to which the given timestamp belongs. It is the same as GasTagConverter.StripTime.
+func (b Boundaries) StromDayStart(timestamp time.Time) time.Time {
+	return b.converter.StripTime(timestamp)
+}
+
+// StromDayEnd returns the exclusive end (German midnight of the following day) of the German Stromtag to which the given timestamp belongs
+func (b Boundaries) StromDayEnd(timestamp time.Time) time.Time {
+	return b.converter.addGermanDays(b.converter.StripTime(timestamp), 1)
+}
+
+// StromDay returns the German Stromtag (from German midnight to German midnight) to which the given timestamp belongs
+func (b Boundaries) StromDay(timestamp time.Time) Interval {
+	return Interval{Start: b.StromDayStart(timestamp), End: b.StromDayEnd(timestamp)}
+}
+
+// GasDay returns the German Gastag (from German 6am to German 6am) to which the given timestamp belongs
+func (b Boundaries) GasDay(timestamp time.Time) Interval {
+	return Interval{Start: b.GasDayStart(timestamp), End: b.GasDayEnd(timestamp)}
+}
+
+// AddGasDays adds n (which may be negative) German days to the given timestamp. The German local time of day stays the same, so a Gastag start stays a Gastag start, even if the days in between are 23h or 25h long.
+func (b Boundaries) AddGasDays(timestamp time.Time, n int) time.Time {
+	return b.converter.addGasDays(timestamp, n)
+}
+
+// GasDaysBetween returns the number of German Gastage from the Gastag to which first belongs to the Gastag to which second belongs. It is negative if second belongs to a Gastag before the one of a.
+func (b Boundaries) GasDaysBetween(first, second time.Time) int {
+	l := b.converter
+	return daysBetween(l.gasDayStart(first).In(l.gasDayLocation), l.gasDayStart(second).In(l.gasDayLocation))
+}
+
+// daysBetween returns the number of calendar days between the local dates of a and b, independent of the actual length (23h, 24h, 25h) of the days in between
+func daysBetween(a, b time.Time) int {
+	aYear, aMonth, aDay := a.Date()
+	bYear, bMonth, bDay := b.Date()
+	aDate := time.Date(aYear, aMonth, aDay, 0, 0, 0, 0, time.UTC)
+	bDate := time.Date(bYear, bMonth, bDay, 0, 0, 0, 0, time.UTC)
+	return int(bDate.Sub(aDate).Hours() / 24)
+}
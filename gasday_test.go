@@ -0,0 +1,78 @@
+package mako_time_converter_test
+
+import (
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/mako_time_converter"
+	"time"
+)
+
+func (s *Suite) Test_GasDayStart_And_End() {
+	type gasDay struct {
+		start time.Time
+		end   time.Time
+	}
+	pairs := map[time.Time]gasDay{
+		// 03:00 German local time belongs to the gas day of the previous day
+		time.Date(2023, 1, 1, 2, 0, 0, 0, time.UTC):           {start: time.Date(2022, 12, 31, 5, 0, 0, 0, time.UTC), end: time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC)},
+		time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC):           {start: time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC), end: time.Date(2023, 1, 2, 5, 0, 0, 0, time.UTC)},
+		time.Date(2023, 1, 1, 4, 59, 59, 999999999, time.UTC): {start: time.Date(2022, 12, 31, 5, 0, 0, 0, time.UTC), end: time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC)},
+		// 2023-03-26 03:00 German local time (CEST) belongs to the 23h gas day that started on 2023-03-25 06:00 CET
+		time.Date(2023, 3, 26, 1, 0, 0, 0, time.UTC): {start: time.Date(2023, 3, 25, 5, 0, 0, 0, time.UTC), end: time.Date(2023, 3, 26, 4, 0, 0, 0, time.UTC)},
+		// the 25h gas day from 2023-10-28 06:00 CEST to 2023-10-29 06:00 CET
+		time.Date(2023, 10, 29, 1, 30, 0, 0, time.UTC): {start: time.Date(2023, 10, 28, 4, 0, 0, 0, time.UTC), end: time.Date(2023, 10, 29, 5, 0, 0, 0, time.UTC)},
+	}
+	converter := getBerlinConverter()
+	boundaries := mako_time_converter.NewBoundaries(converter)
+	for input, expected := range pairs {
+		then.AssertThat(s.T(), boundaries.GasDayStart(input), is.EqualTo(expected.start))
+		then.AssertThat(s.T(), boundaries.GasDayEnd(input), is.EqualTo(expected.end))
+		then.AssertThat(s.T(), converter.IsGerman6Am(boundaries.GasDayStart(input)), is.True())
+	}
+}
+
+func (s *Suite) Test_StromDayStart_And_End() {
+	converter := getBerlinConverter()
+	boundaries := mako_time_converter.NewBoundaries(converter)
+	then.AssertThat(s.T(), boundaries.StromDayStart(time.Date(2023, 3, 26, 12, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2023, 3, 25, 23, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), boundaries.StromDayEnd(time.Date(2023, 3, 26, 12, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2023, 3, 26, 22, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), boundaries.StromDayEnd(time.Date(2023, 10, 29, 12, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2023, 10, 29, 23, 0, 0, 0, time.UTC)))
+}
+
+func (s *Suite) Test_AddGasDays() {
+	converter := getBerlinConverter()
+	boundaries := mako_time_converter.NewBoundaries(converter)
+	gasDayStart := time.Date(2023, 3, 20, 5, 0, 0, 0, time.UTC)
+	then.AssertThat(s.T(), boundaries.AddGasDays(gasDayStart, 10), is.EqualTo(time.Date(2023, 3, 30, 4, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), boundaries.AddGasDays(time.Date(2023, 3, 30, 4, 0, 0, 0, time.UTC), -10), is.EqualTo(gasDayStart))
+	then.AssertThat(s.T(), boundaries.AddGasDays(gasDayStart, 0), is.EqualTo(gasDayStart))
+	for days := -400; days <= 400; days += 7 {
+		then.AssertThat(s.T(), converter.IsGerman6Am(boundaries.AddGasDays(gasDayStart, days)), is.True())
+	}
+}
+
+func (s *Suite) Test_GasDaysBetween() {
+	converter := getBerlinConverter()
+	boundaries := mako_time_converter.NewBoundaries(converter)
+	then.AssertThat(s.T(), boundaries.GasDaysBetween(time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 5, 0, 0, 0, time.UTC)), is.EqualTo(365))
+	then.AssertThat(s.T(), boundaries.GasDaysBetween(time.Date(2024, 1, 1, 5, 0, 0, 0, time.UTC), time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC)), is.EqualTo(-365))
+	// both belong to the 23h gas day 2023-03-25
+	then.AssertThat(s.T(), boundaries.GasDaysBetween(time.Date(2023, 3, 25, 5, 0, 0, 0, time.UTC), time.Date(2023, 3, 26, 3, 59, 0, 0, time.UTC)), is.EqualTo(0))
+	then.AssertThat(s.T(), boundaries.GasDaysBetween(time.Date(2023, 3, 25, 5, 0, 0, 0, time.UTC), time.Date(2023, 3, 26, 4, 0, 0, 0, time.UTC)), is.EqualTo(1))
+	// the 25h gas day 2023-10-28
+	then.AssertThat(s.T(), boundaries.GasDaysBetween(time.Date(2023, 10, 28, 4, 0, 0, 0, time.UTC), time.Date(2023, 10, 29, 4, 30, 0, 0, time.UTC)), is.EqualTo(0))
+	then.AssertThat(s.T(), boundaries.GasDaysBetween(time.Date(2023, 3, 1, 5, 0, 0, 0, time.UTC), time.Date(2023, 11, 1, 5, 0, 0, 0, time.UTC)), is.EqualTo(245))
+}
+
+// wrappedConverter is a GasTagConverter that is implemented outside the package (like a mock)
+type wrappedConverter struct {
+	mako_time_converter.GasTagConverter
+}
+
+func (s *Suite) Test_Boundaries_Of_External_Converter() {
+	converter, err := mako_time_converter.NewGasTagConverterForMarket(utcGasDayMarket())
+	then.AssertThat(s.T(), err, is.Nil())
+	boundaries := mako_time_converter.NewBoundaries(wrappedConverter{converter})
+	then.AssertThat(s.T(), boundaries.GasDayStart(time.Date(2023, 7, 1, 4, 30, 0, 0, time.UTC)), is.EqualTo(time.Date(2023, 6, 30, 5, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), boundaries.StromDayStart(time.Date(2023, 7, 1, 5, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2023, 6, 30, 22, 0, 0, 0, time.UTC)))
+}
@@ -1,41 +1,89 @@
 package mako_time_converter
 
 import (
+	"context"
 	"fmt"
 	"github.com/go-playground/validator/v10"
-	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
 	"log"
 	"time"
 )
 
 // GasTagConverter is a struct to convert to and from German "Gas-Tag" (which always starts at 6AM German local time)
 type GasTagConverter interface {
+	// Location returns the location in which German local times (midnight, 6am, dates) are evaluated
+	Location() *time.Location
+	// Market returns the MarketDefinition of the converter (GermanMarket unless the converter has been created by NewGasTagConverterForMarket)
+	Market() MarketDefinition
 	// IsGermanMidnight returns true iff the given timestamp is the beginning of a German Stromtag (midnight local time)
 	IsGermanMidnight(timestamp time.Time) bool
-	// IsGerman6Am returns true if the given timestamp is the beginning of a German Gastag (6AM local time)
+	// IsGerman6Am returns true if the given timestamp is the beginning of a German Gastag (6AM local time) or, more generally, the gas day start of the Market
 	IsGerman6Am(timestamp time.Time) bool
-	// Convert6AamToMidnight converts the given local 6Am timestamp to German midnight of the same German day
+	// Convert6AamToMidnight converts the given local 6Am timestamp to German midnight of the same German day. It returns a NotGerman6AmError if the timestamp is not German 6am.
 	Convert6AamToMidnight(timestamp time.Time) (time.Time, error)
-	// ConvertMidnightTo6Am converts the given local 6Am timestamp to German midnight of the same German day
+	// ConvertMidnightTo6Am converts the given German midnight timestamp to local 6Am of the same German day. It returns a NotGermanMidnightError if the timestamp is not German midnight.
 	ConvertMidnightTo6Am(timestamp time.Time) (time.Time, error)
 	// StripTime removes all hours, minutes, seconds, milliseconds (in german local time) from the given timestamp. This is similar to a "round down" or "floor" in German local time.
 	StripTime(timestamp time.Time) time.Time
-	// Convert  converts the given timestamp to a DateTimeConversionConfiguration.Target by applying all transformations which are derived from the given configuration time is described by DateTimeConversionConfiguration.Source.
+	// Convert  converts the given timestamp to a DateTimeConversionConfiguration.Target by applying all transformations which are derived from the given configuration time is described by DateTimeConversionConfiguration.Source. It returns an InvalidConfigurationError if the configuration is invalid.
 	Convert(timestamp time.Time, configuration DateTimeConversionConfiguration) (time.Time, error)
+	// ConvertInterval converts the given interval by applying the start date rules of the configuration to the Interval.Start and the end date rules (IsEndDate, EndDateTimeKind) to the Interval.End. It returns an InvalidIntervalError if the Interval.End is before the Interval.Start, either before or after the conversion.
+	ConvertInterval(interval Interval, configuration DateTimeConversionConfiguration) (Interval, error)
+	// ConvertAll converts all the given timestamps using the same configuration. The configuration is validated only once; if it is invalid, an error is returned and nothing is converted. Otherwise, there is one ConversionResult per timestamp (in the same order) and errors are reported per timestamp without aborting the batch.
+	ConvertAll(timestamps []time.Time, configuration DateTimeConversionConfiguration) ([]ConversionResult, error)
+	// ConvertStream converts all timestamps received from the in channel using the same configuration. The configuration is validated only once; if it is invalid, an error is returned and the in channel is not read. Otherwise, there is one ConversionResult per received timestamp (in the same order) on the returned channel. The returned channel is closed after the in channel has been closed.
+	// If the ctx is done, the conversion stops and the returned channel is closed, too; cancel the ctx if you stop reading from the returned channel early, otherwise the converting goroutine is leaked.
+	ConvertStream(ctx context.Context, in <-chan time.Time, configuration DateTimeConversionConfiguration) (<-chan ConversionResult, error)
+	// Compile validates the given configuration once and returns a ConversionPlan which applies the configuration without validating it again. Use it if you convert many timestamps with the same configuration.
+	Compile(configuration DateTimeConversionConfiguration) (ConversionPlan, error)
 }
 
 type locationBasedGasTagConverter struct {
 	location *time.Location
+	market   MarketDefinition
+	// gasDayLocation is the location in which the gas day start of the market is expressed (usually the location itself)
+	gasDayLocation *time.Location
+}
+
+func newLocationBasedGasTagConverter(location *time.Location, market MarketDefinition) locationBasedGasTagConverter {
+	return locationBasedGasTagConverter{location: location, market: market, gasDayLocation: market.gasDayLocation(location)}
 }
 
 // NewGasTagConverter returns a GasTagConverter that internally uses the timezone data from the timezone with the given zoneName (e.g. "Europe/Berlin"). It requires the tzdata to be available on the system and will panic if this is not the case.
+// Use NewGasTagConverterE if you prefer an error over a panic.
 func NewGasTagConverter(zoneName string) GasTagConverter {
+	converter, err := NewGasTagConverterE(zoneName)
+	if err != nil {
+		log.Panic(err)
+	}
+	return converter
+}
+
+// NewGasTagConverterE returns a GasTagConverter that internally uses the timezone data from the timezone with the given zoneName (e.g. "Europe/Berlin"). If the tzdata are not available on the system, it returns an error that wraps both ErrTimezoneDataMissing and the original error of time.LoadLocation.
+func NewGasTagConverterE(zoneName string) (GasTagConverter, error) {
+	location, err := loadLocation(zoneName)
+	if err != nil {
+		return nil, err
+	}
+	return NewGasTagConverterForLocation(location)
+}
+
+// loadLocation loads the location with the given zoneName and returns an error that wraps ErrTimezoneDataMissing if the tzdata are not available
+func loadLocation(zoneName string) (*time.Location, error) {
 	location, err := time.LoadLocation(zoneName)
 	if err != nil {
-		errorMsg := fmt.Errorf("the timezone data for '%s' could not be found. Import \"time/tzdata\" anywhere in your project or build with `-tags timetzdata`: https://pkg.go.dev/time/tzdata", zoneName)
-		log.Panic(errorMsg)
+		return nil, fmt.Errorf("%w: the timezone data for '%s' could not be found. Import \"time/tzdata\" anywhere in your project or build with `-tags timetzdata`: https://pkg.go.dev/time/tzdata: %w", ErrTimezoneDataMissing, zoneName, err)
 	}
-	return locationBasedGasTagConverter{location: location}
+	return location, nil
+}
+
+// NewGasTagConverterForLocation returns a GasTagConverter that uses the given location (which must not be nil) and the gas day start of the GermanMarket
+func NewGasTagConverterForLocation(location *time.Location) (GasTagConverter, error) {
+	if location == nil {
+		return nil, ErrNilLocation
+	}
+	market := GermanMarket()
+	market.ZoneName = location.String()
+	return newLocationBasedGasTagConverter(location, market), nil
 }
 
 // ToLocalTimeConverter contains a method to convert a time into a local time. This will, in most cases, happen on the basis of timezone data, but you are free to write your own conversion, although you're probably missing out on details at one point.
@@ -44,6 +92,10 @@ type ToLocalTimeConverter interface {
 	toLocalTime(timestamp time.Time) time.Time
 }
 
+func (l locationBasedGasTagConverter) Location() *time.Location {
+	return l.location
+}
+
 func (l locationBasedGasTagConverter) toLocalTime(timestamp time.Time) time.Time {
 	return timestamp.In(l.location)
 }
@@ -55,26 +107,24 @@ func (l locationBasedGasTagConverter) IsGermanMidnight(timestamp time.Time) bool
 }
 
 func (l locationBasedGasTagConverter) IsGerman6Am(timestamp time.Time) bool {
-	localTime := l.toLocalTime(timestamp)
-	hour, minute, sec := localTime.Clock()
-	return hour == 6 && minute == 0 && sec == 0
+	hour, minute, sec := timestamp.In(l.gasDayLocation).Clock()
+	return hour == l.market.GasDayStartHour && minute == l.market.GasDayStartMinute && sec == 0
 }
 
 func (l locationBasedGasTagConverter) Convert6AamToMidnight(timestamp time.Time) (time.Time, error) {
 	if !l.IsGerman6Am(timestamp) {
-		return time.Time{}, fmt.Errorf("the given time %v is not German 6am", timestamp)
+		return time.Time{}, NotGerman6AmError{Timestamp: timestamp, LocalTime: l.toLocalTime(timestamp)}
 	}
-	return l.StripTime(timestamp), nil
+	return l.stromDayStartOn(timestamp.In(l.gasDayLocation).Date()), nil
 }
 
 func (l locationBasedGasTagConverter) ConvertMidnightTo6Am(timestamp time.Time) (time.Time, error) {
 	if !l.IsGermanMidnight(timestamp) {
-		return time.Time{}, fmt.Errorf("the given time %v is not German midnight", timestamp)
+		return time.Time{}, NotGermanMidnightError{Timestamp: timestamp, LocalTime: l.toLocalTime(timestamp)}
 	}
 	localMidnight := l.toLocalTime(timestamp)
 	year, month, day := localMidnight.Date()
-	local6Am := time.Date(year, month, day, 6, 0, 0, 0, l.location)
-	return local6Am.UTC(), nil
+	return l.gasDayStartOn(year, month, day), nil
 }
 
 func (l locationBasedGasTagConverter) StripTime(timestamp time.Time) time.Time {
@@ -84,65 +134,45 @@ func (l locationBasedGasTagConverter) StripTime(timestamp time.Time) time.Time {
 	return localMidnight.UTC()
 }
 
-func (l locationBasedGasTagConverter) addGermanDay(timestamp time.Time) time.Time {
+// addGasDays adds the given number of days (may be negative) in the location in which the gas day start is expressed, so that a gas day start stays a gas day start
+func (l locationBasedGasTagConverter) addGasDays(timestamp time.Time, days int) time.Time {
+	return timestamp.In(l.gasDayLocation).AddDate(0, 0, days).UTC()
+}
+
+// addGermanDays adds the given number of days (may be negative) in German local time, so that the local time of day stays the same even if a DST transition lies in between
+func (l locationBasedGasTagConverter) addGermanDays(timestamp time.Time, days int) time.Time {
 	localtime := l.toLocalTime(timestamp)
-	return localtime.AddDate(0, 0, 1).UTC()
+	return localtime.AddDate(0, 0, days).UTC()
+}
+
+func (l locationBasedGasTagConverter) addGermanDay(timestamp time.Time) time.Time {
+	return l.addGermanDays(timestamp, 1)
 }
 func (l locationBasedGasTagConverter) subtractGermanDay(timestamp time.Time) time.Time {
-	localtime := l.toLocalTime(timestamp)
-	return localtime.AddDate(0, 0, -1).UTC()
+	return l.addGermanDays(timestamp, -1)
 }
 
-func (l locationBasedGasTagConverter) Convert(timestamp time.Time, configuration DateTimeConversionConfiguration) (time.Time, error) {
+// configurationValidator validates DateTimeConversionConfigurations. A validator.Validate is safe for concurrent use and caches the struct information, so it is only created once.
+var configurationValidator = newConfigurationValidator()
+
+func newConfigurationValidator() *validator.Validate {
 	validate := validator.New()
 	validate.RegisterStructValidation(DateTimeConversionConfigurationStructLevelValidator, DateTimeConversionConfiguration{})
-	err := validate.Struct(configuration)
-	if err != nil {
-		return time.Time{}, err
-	}
-	result := timestamp
-	if configuration.Source.StripTime {
-		result = l.StripTime(result)
-	}
-	if configuration.Source == configuration.Target {
-		// both are the same, no conversion needed
-		return result.UTC(), nil
-	}
-	if configuration.Source.IsGas { // this implies that the target is also gas, because otherwise the configuration would be invalid
-		// handle gas stuff here
-		if *configuration.Source.IsGasTagAware && !*configuration.Target.IsGasTagAware {
-			// convert from gas-tag to non-gas-tag
-			if l.IsGerman6Am(result) {
-				result, err = l.Convert6AamToMidnight(result)
-				if err != nil { // the error won't happen because Convert6AmToMidnight only returns an error if the datetime is not 6Am (which we checked before)
-					return time.Time{}, err
-				}
-			}
-		}
-		if !*configuration.Source.IsGasTagAware && *configuration.Target.IsGasTagAware {
-			if l.IsGermanMidnight(result) {
-				result, err = l.ConvertMidnightTo6Am(result)
-				if err != nil { //  the error won't happen because ConvertMidnightTo6Am only returns an error if the datetime is not midnight (which we checked before)
-					return time.Time{}, err
-				}
-			}
-		}
-	}
-	// else { handle strom-only stuff here }
-
-	if configuration.Source.IsEndDate && configuration.Target.IsEndDate && *configuration.Source.EndDateTimeKind != *configuration.Target.EndDateTimeKind {
-		if *configuration.Source.EndDateTimeKind == enddatetimekind.INCLUSIVE { // implicit: target is exclusive
-			// convert from inclusive to exclusive
-			result = l.addGermanDay(result)
-		}
-		if *configuration.Source.EndDateTimeKind == enddatetimekind.EXCLUSIVE { // implicit: target is inclusive
-			// convert from exclusive to inclusive
-			result = l.subtractGermanDay(result)
-		}
+	return validate
+}
+
+// validateConfiguration returns an InvalidConfigurationError if the given configuration is invalid
+func validateConfiguration(configuration DateTimeConversionConfiguration) error {
+	if err := configurationValidator.Struct(configuration); err != nil {
+		return newInvalidConfigurationError(err)
 	}
+	return nil
+}
 
-	if configuration.Target.StripTime {
-		result = l.StripTime(result)
+func (l locationBasedGasTagConverter) Convert(timestamp time.Time, configuration DateTimeConversionConfiguration) (time.Time, error) {
+	plan, err := l.Compile(configuration)
+	if err != nil {
+		return time.Time{}, err
 	}
-	return result.UTC(), nil
+	return plan.Apply(timestamp)
 }
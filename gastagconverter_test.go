@@ -1,6 +1,7 @@
 package mako_time_converter_test
 
 import (
+	"errors"
 	"github.com/corbym/gocrest/is"
 	"github.com/corbym/gocrest/then"
 	"github.com/hochfrequenz/mako_time_converter"
@@ -226,6 +227,30 @@ func (s *Suite) Test_NewGastagConverter_Panics_for_Unknown_Timezone() {
 	assert.Panics(s.T(), func() { mako_time_converter.NewGasTagConverter("OtherContinent/IDontKnow") })
 }
 
+func (s *Suite) Test_NewGastagConverterE_Returns_Error_for_Unknown_Timezone() {
+	converter, err := mako_time_converter.NewGasTagConverterE("OtherContinent/IDontKnow")
+	then.AssertThat(s.T(), converter == nil, is.True())
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrTimezoneDataMissing), is.True())
+	then.AssertThat(s.T(), err.Error(), is.StringContaining("unknown time zone OtherContinent/IDontKnow")) // the original error of time.LoadLocation is wrapped
+}
+
+func (s *Suite) Test_NewGastagConverterE_Returns_Converter_for_Known_Timezone() {
+	converter, err := mako_time_converter.NewGasTagConverterE("Europe/Berlin")
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), converter.IsGermanMidnight(time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC)), is.True())
+}
+
+func (s *Suite) Test_NewGastagConverterForLocation() {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	converter, err := mako_time_converter.NewGasTagConverterForLocation(berlin)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), converter.IsGerman6Am(time.Date(2022, 12, 31, 5, 0, 0, 0, time.UTC)), is.True())
+
+	converter, err = mako_time_converter.NewGasTagConverterForLocation(nil)
+	then.AssertThat(s.T(), converter == nil, is.True())
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrNilLocation), is.True())
+}
+
 func (s *Suite) Test_StripTime_On_Source_Side() {
 	pairs := map[time.Time]time.Time{
 		time.Date(2023, 05, 30, 22, 1, 2, 3, time.UTC): time.Date(2023, 05, 31, 22, 0, 0, 0, time.UTC)}
@@ -271,3 +296,49 @@ func (s *Suite) Test_Same_Source_And_Target_Leads_To_Utc_Conversion_Only() {
 		then.AssertThat(s.T(), actual, is.EqualTo(expected))
 	}
 }
+
+func (s *Suite) Test_Time_Of_Day_End_Date_Kinds() {
+	type testCase struct {
+		source   mako_time_converter.DateTimeConfiguration
+		target   mako_time_converter.DateTimeConfiguration
+		input    time.Time
+		expected time.Time
+	}
+	stromEnd := func(kind enddatetimekind.EndDateTimeKind) mako_time_converter.DateTimeConfiguration {
+		return mako_time_converter.DateTimeConfiguration{IsEndDate: true, EndDateTimeKind: pointer(kind)}
+	}
+	gasEnd := func(isGasTagAware bool, kind enddatetimekind.EndDateTimeKind) mako_time_converter.DateTimeConfiguration {
+		return mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(isGasTagAware), IsEndDate: true, EndDateTimeKind: pointer(kind)}
+	}
+	testCases := []testCase{
+		{source: stromEnd(enddatetimekind.INCLUSIVE_LAST_SECOND), target: stromEnd(enddatetimekind.EXCLUSIVE), input: time.Date(2023, 1, 31, 22, 59, 59, 0, time.UTC), expected: time.Date(2023, 1, 31, 23, 0, 0, 0, time.UTC)},
+		{source: stromEnd(enddatetimekind.EXCLUSIVE), target: stromEnd(enddatetimekind.INCLUSIVE_LAST_MILLISECOND), input: time.Date(2023, 1, 31, 23, 0, 0, 0, time.UTC), expected: time.Date(2023, 1, 31, 22, 59, 59, 999000000, time.UTC)},
+		{source: stromEnd(enddatetimekind.INCLUSIVE_LAST_HOUR), target: stromEnd(enddatetimekind.INCLUSIVE_LAST_QUARTER_HOUR), input: time.Date(2023, 3, 25, 22, 0, 0, 0, time.UTC), expected: time.Date(2023, 3, 25, 22, 45, 0, 0, time.UTC)},
+		{source: stromEnd(enddatetimekind.INCLUSIVE_LAST_SECOND), target: stromEnd(enddatetimekind.INCLUSIVE), input: time.Date(2023, 3, 26, 21, 59, 59, 0, time.UTC), expected: time.Date(2023, 3, 25, 23, 0, 0, 0, time.UTC)},
+		// 05:45 German local time is the last quarter hour of the Gastag
+		{source: gasEnd(true, enddatetimekind.INCLUSIVE_LAST_QUARTER_HOUR), target: gasEnd(false, enddatetimekind.INCLUSIVE), input: time.Date(2023, 2, 1, 4, 45, 0, 0, time.UTC), expected: time.Date(2023, 1, 30, 23, 0, 0, 0, time.UTC)},
+		{source: gasEnd(false, enddatetimekind.INCLUSIVE), target: gasEnd(true, enddatetimekind.INCLUSIVE_LAST_MILLISECOND), input: time.Date(2023, 1, 30, 23, 0, 0, 0, time.UTC), expected: time.Date(2023, 2, 1, 4, 59, 59, 999000000, time.UTC)},
+		{source: gasEnd(true, enddatetimekind.INCLUSIVE_LAST_SECOND), target: gasEnd(false, enddatetimekind.INCLUSIVE_LAST_SECOND), input: time.Date(2023, 2, 1, 4, 59, 59, 0, time.UTC), expected: time.Date(2023, 1, 31, 22, 59, 59, 0, time.UTC)},
+		// a timestamp which is not the end of a day is not shifted between gas and non gas days
+		{source: gasEnd(true, enddatetimekind.INCLUSIVE_LAST_SECOND), target: gasEnd(false, enddatetimekind.EXCLUSIVE), input: time.Date(2023, 2, 1, 10, 59, 59, 0, time.UTC), expected: time.Date(2023, 2, 1, 11, 0, 0, 0, time.UTC)},
+	}
+	converter := getBerlinConverter()
+	for _, tc := range testCases {
+		actual, err := converter.Convert(tc.input, mako_time_converter.DateTimeConversionConfiguration{Source: tc.source, Target: tc.target})
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), actual, is.EqualTo(tc.expected))
+	}
+}
+
+func (s *Suite) Test_Time_Of_Day_End_Date_Kinds_Cannot_Be_Stripped() {
+	_, err := getBerlinConverter().Convert(time.Now(), mako_time_converter.DateTimeConversionConfiguration{
+		Source: mako_time_converter.DateTimeConfiguration{IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.EXCLUSIVE)},
+		Target: mako_time_converter.DateTimeConfiguration{IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.INCLUSIVE_LAST_SECOND), StripTime: true},
+	})
+	var invalidConfigurationError mako_time_converter.InvalidConfigurationError
+	then.AssertThat(s.T(), errors.As(err, &invalidConfigurationError), is.True())
+	then.AssertThat(s.T(), invalidConfigurationError.Field, is.EqualTo("Target.StripTime"))
+
+	_, err = mako_time_converter.NewDateTimeConfiguration().EndDate(enddatetimekind.INCLUSIVE_LAST_QUARTER_HOUR).StripTime().Build()
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+}
@@ -0,0 +1,51 @@
+package mako_time_converter
+
+import (
+	"fmt"
+	"time"
+)
+
+// Interval is a period of time described by a Start and an End (e.g. a contract period, a Zählzeitraum or a Bilanzierungszeitraum). Whether the End is meant inclusive or exclusive is not part of the Interval itself but is described by the DateTimeConversionConfiguration that is used to convert it.
+type Interval struct {
+	// Start is the beginning of the interval
+	Start time.Time `json:"start"`
+	// End is the end of the interval
+	End time.Time `json:"end"`
+}
+
+// Validate returns an InvalidIntervalError if the End of the interval is before its Start
+func (i Interval) Validate() error {
+	if i.End.Before(i.Start) {
+		return InvalidIntervalError{Interval: i}
+	}
+	return nil
+}
+
+// startConfiguration returns the configuration that is used to convert the Start of an Interval. It is the given configuration without the end date specific settings.
+func startConfiguration(configuration DateTimeConversionConfiguration) DateTimeConversionConfiguration {
+	result := configuration
+	result.Source.IsEndDate = false
+	result.Source.EndDateTimeKind = nil
+	result.Target.IsEndDate = false
+	result.Target.EndDateTimeKind = nil
+	return result
+}
+
+func (l locationBasedGasTagConverter) ConvertInterval(interval Interval, configuration DateTimeConversionConfiguration) (Interval, error) {
+	if err := interval.Validate(); err != nil {
+		return Interval{}, err
+	}
+	start, err := l.Convert(interval.Start, startConfiguration(configuration))
+	if err != nil {
+		return Interval{}, err
+	}
+	end, err := l.Convert(interval.End, configuration)
+	if err != nil {
+		return Interval{}, err
+	}
+	result := Interval{Start: start, End: end}
+	if err = result.Validate(); err != nil {
+		return Interval{}, fmt.Errorf("the conversion of %v-%v resulted in an invalid interval: %w", interval.Start, interval.End, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,59 @@
+package mako_time_converter_test
+
+import (
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/mako_time_converter"
+	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
+	"time"
+)
+
+func (s *Suite) Test_ConvertInterval_Gas_Inclusive_Non_Gastag_Aware_To_Exclusive_Gastag_Aware() {
+	pairs := map[mako_time_converter.Interval]mako_time_converter.Interval{
+		{Start: time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC), End: time.Date(2023, 1, 30, 23, 0, 0, 0, time.UTC)}: {Start: time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC), End: time.Date(2023, 2, 1, 5, 0, 0, 0, time.UTC)},
+		{Start: time.Date(2023, 5, 31, 22, 0, 0, 0, time.UTC), End: time.Date(2023, 6, 29, 22, 0, 0, 0, time.UTC)}:  {Start: time.Date(2023, 6, 1, 4, 0, 0, 0, time.UTC), End: time.Date(2023, 7, 1, 4, 0, 0, 0, time.UTC)},
+	}
+	conversion := mako_time_converter.DateTimeConversionConfiguration{
+		Source: mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(false), IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.INCLUSIVE)},
+		Target: mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(true), IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.EXCLUSIVE)},
+	}
+	converter := getBerlinConverter()
+	for input, expected := range pairs {
+		actual, err := converter.ConvertInterval(input, conversion)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), actual, is.EqualTo(expected))
+	}
+	invertedConfig := conversion.Invert()
+	for expected, input := range pairs {
+		actual, err := converter.ConvertInterval(input, invertedConfig)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), actual, is.EqualTo(expected))
+	}
+}
+
+func (s *Suite) Test_ConvertInterval_Rejects_End_Before_Start() {
+	conversion := mako_time_converter.DateTimeConversionConfiguration{
+		Source: mako_time_converter.DateTimeConfiguration{IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.EXCLUSIVE)},
+		Target: mako_time_converter.DateTimeConfiguration{IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.INCLUSIVE)},
+	}
+	converter := getBerlinConverter()
+	invalidInterval := mako_time_converter.Interval{Start: time.Date(2023, 1, 2, 23, 0, 0, 0, time.UTC), End: time.Date(2023, 1, 1, 23, 0, 0, 0, time.UTC)}
+	_, err := converter.ConvertInterval(invalidInterval, conversion)
+	then.AssertThat(s.T(), err, is.Not(is.Nil()))
+
+	// an exclusive end which equals the start (empty interval) becomes an inclusive end before the start
+	emptyInterval := mako_time_converter.Interval{Start: time.Date(2023, 1, 1, 23, 0, 0, 0, time.UTC), End: time.Date(2023, 1, 1, 23, 0, 0, 0, time.UTC)}
+	_, err = converter.ConvertInterval(emptyInterval, conversion)
+	then.AssertThat(s.T(), err, is.Not(is.Nil()))
+}
+
+func (s *Suite) Test_ConvertInterval_Rejects_Invalid_Configuration() {
+	conversion := mako_time_converter.DateTimeConversionConfiguration{
+		Source: mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(true)},
+		Target: mako_time_converter.DateTimeConfiguration{IsGas: false},
+	}
+	converter := getBerlinConverter()
+	interval := mako_time_converter.Interval{Start: time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC), End: time.Date(2023, 2, 1, 5, 0, 0, 0, time.UTC)}
+	_, err := converter.ConvertInterval(interval, conversion)
+	then.AssertThat(s.T(), err, is.Not(is.Nil()))
+}
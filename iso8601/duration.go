@@ -0,0 +1,132 @@
+package iso8601
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration is an ISO 8601 duration like "P1M" or "P1DT12H". The calendar part (Years, Months, Days) is applied in German local time, so that e.g. "P1M" always moves to the same local time of day in the next month, no matter how many hours the month has. The Time part is an absolute duration.
+type Duration struct {
+	Years  int
+	Months int
+	// Days contains the days and weeks (1 week = 7 days) of the duration
+	Days int
+	// Time is the time part of the duration (hours, minutes, seconds)
+	Time time.Duration
+}
+
+// durationPattern matches ISO 8601 durations with optional calendar and time components, e.g. P1Y2M3W4DT5H6M7.5S
+var durationPattern = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+// ParseDuration parses an ISO 8601 duration like "P1M", "P1Y", "P14D", "P2W" or "PT15M"
+func ParseDuration(value string) (Duration, error) {
+	matches := durationPattern.FindStringSubmatch(value)
+	if matches == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return Duration{}, fmt.Errorf("%w: '%s' is not an ISO 8601 duration", ErrInvalidInterval, value)
+	}
+	invalid := func(reason string) (Duration, error) {
+		return Duration{}, fmt.Errorf("%w: '%s' is not a valid ISO 8601 duration: %s", ErrInvalidInterval, value, reason)
+	}
+	// years, months, weeks, days, hours and minutes; the seconds may have a fraction
+	var numbers [6]int
+	for index := range numbers {
+		if matches[index+1] == "" {
+			continue
+		}
+		number, err := strconv.Atoi(matches[index+1]) // fails if the number overflows an int
+		if err != nil {
+			return invalid(err.Error())
+		}
+		numbers[index] = number
+	}
+	years, months, weeks, days, hours, minutes := numbers[0], numbers[1], numbers[2], numbers[3], numbers[4], numbers[5]
+	if weeks > (math.MaxInt-days)/7 {
+		return invalid("the days overflow")
+	}
+	result := Duration{Years: years, Months: months, Days: weeks*7 + days}
+	var ok bool
+	if result.Time, ok = multiplyDuration(hours, time.Hour); !ok {
+		return invalid("the hours overflow")
+	}
+	minuteDuration, ok := multiplyDuration(minutes, time.Minute)
+	if !ok {
+		return invalid("the minutes overflow")
+	}
+	if result.Time, ok = addDurations(result.Time, minuteDuration); !ok {
+		return invalid("the time overflows")
+	}
+	if matches[7] != "" {
+		seconds, err := strconv.ParseFloat(strings.Replace(matches[7], ",", ".", 1), 64)
+		if err != nil || seconds*float64(time.Second) >= math.MaxInt64 {
+			return invalid("the seconds overflow")
+		}
+		if result.Time, ok = addDurations(result.Time, time.Duration(seconds*float64(time.Second))); !ok {
+			return invalid("the time overflows")
+		}
+	}
+	return result, nil
+}
+
+// multiplyDuration returns n*unit; ok is false if the result overflows a time.Duration
+func multiplyDuration(n int, unit time.Duration) (result time.Duration, ok bool) {
+	if int64(n) > math.MaxInt64/int64(unit) {
+		return 0, false
+	}
+	return time.Duration(n) * unit, true
+}
+
+// addDurations returns a+b for non-negative durations; ok is false if the result overflows a time.Duration
+func addDurations(a, b time.Duration) (result time.Duration, ok bool) {
+	if a > math.MaxInt64-b {
+		return 0, false
+	}
+	return a + b, true
+}
+
+// String returns the duration in ISO 8601 format, e.g. "P1M"
+func (d Duration) String() string {
+	var builder strings.Builder
+	builder.WriteString("P")
+	if d.Years != 0 {
+		builder.WriteString(fmt.Sprintf("%dY", d.Years))
+	}
+	if d.Months != 0 {
+		builder.WriteString(fmt.Sprintf("%dM", d.Months))
+	}
+	if d.Days != 0 {
+		builder.WriteString(fmt.Sprintf("%dD", d.Days))
+	}
+	if d.Time != 0 {
+		builder.WriteString("T")
+		remainder := d.Time
+		if hours := remainder / time.Hour; hours != 0 {
+			builder.WriteString(fmt.Sprintf("%dH", hours))
+			remainder -= hours * time.Hour
+		}
+		if minutes := remainder / time.Minute; minutes != 0 {
+			builder.WriteString(fmt.Sprintf("%dM", minutes))
+			remainder -= minutes * time.Minute
+		}
+		if remainder != 0 {
+			builder.WriteString(strconv.FormatFloat(remainder.Seconds(), 'f', -1, 64) + "S")
+		}
+	}
+	if builder.Len() == 1 {
+		return "PT0S"
+	}
+	return builder.String()
+}
+
+// addTo adds the duration to the given timestamp. The calendar part is added in the given location; the time part is added afterwards as an absolute duration.
+func (d Duration) addTo(timestamp time.Time, location *time.Location) time.Time {
+	return timestamp.In(location).AddDate(d.Years, d.Months, d.Days).Add(d.Time).UTC()
+}
+
+// subtractFrom subtracts the duration from the given timestamp (the inverse of addTo)
+func (d Duration) subtractFrom(timestamp time.Time, location *time.Location) time.Time {
+	return timestamp.Add(-d.Time).In(location).AddDate(-d.Years, -d.Months, -d.Days).UTC()
+}
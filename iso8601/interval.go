@@ -0,0 +1,119 @@
+// Package iso8601 parses and formats ISO 8601 intervals (e.g. "2023-01-01T05:00:00Z/2023-02-01T05:00:00Z" or "2023-01-01/P1M") with the German day semantics of Marktkommunikation (MaKo).
+package iso8601
+
+import (
+	"errors"
+	"fmt"
+	"github.com/hochfrequenz/mako_time_converter"
+	"strings"
+	"time"
+)
+
+// ErrInvalidInterval is returned if a string is not a supported ISO 8601 interval
+var ErrInvalidInterval = errors.New("invalid ISO 8601 interval")
+
+const dateLayout = "2006-01-02"
+
+// dayStart returns the start of the German day (German midnight for Strom, German 6am for Gas) on the given German local date
+func dayStart(date time.Time, converter mako_time_converter.GasTagConverter, isGasDay bool) time.Time {
+	localMidnight := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, converter.Location())
+	if !isGasDay {
+		return localMidnight.UTC()
+	}
+	result, _ := converter.ConvertMidnightTo6Am(localMidnight) // no error, because localMidnight is German midnight
+	return result
+}
+
+// parseTimestamp parses an RFC 3339 date time or a date. A date is the start of the respective German day (see dayStart).
+func parseTimestamp(value string, converter mako_time_converter.GasTagConverter, isGasDay bool) (time.Time, error) {
+	if timestamp, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return timestamp.UTC(), nil
+	}
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: '%s' is neither an RFC 3339 date time nor a date", ErrInvalidInterval, value)
+	}
+	return dayStart(date, converter, isGasDay), nil
+}
+
+// ParseInterval parses an ISO 8601 interval of the forms "start/end", "start/duration" or "duration/end". Start and end may be RFC 3339 date times or dates. A date is understood as the start of the German day on that date: German midnight if isGasDay is false, German 6am if isGasDay is true. Durations are applied in German local time (see Duration). As usual for ISO 8601, the end of the resulting interval is exclusive.
+func ParseInterval(value string, converter mako_time_converter.GasTagConverter, isGasDay bool) (mako_time_converter.Interval, error) {
+	startValue, endValue, found := strings.Cut(value, "/")
+	if !found {
+		return mako_time_converter.Interval{}, fmt.Errorf("%w: '%s' has no '/'", ErrInvalidInterval, value)
+	}
+	var result mako_time_converter.Interval
+	var err error
+	switch {
+	case strings.HasPrefix(startValue, "P") && strings.HasPrefix(endValue, "P"):
+		return mako_time_converter.Interval{}, fmt.Errorf("%w: '%s' consists of two durations", ErrInvalidInterval, value)
+	case strings.HasPrefix(endValue, "P"):
+		if result.Start, err = parseTimestamp(startValue, converter, isGasDay); err != nil {
+			return mako_time_converter.Interval{}, err
+		}
+		duration, err := ParseDuration(endValue)
+		if err != nil {
+			return mako_time_converter.Interval{}, err
+		}
+		result.End = duration.addTo(result.Start, converter.Location())
+	case strings.HasPrefix(startValue, "P"):
+		if result.End, err = parseTimestamp(endValue, converter, isGasDay); err != nil {
+			return mako_time_converter.Interval{}, err
+		}
+		duration, err := ParseDuration(startValue)
+		if err != nil {
+			return mako_time_converter.Interval{}, err
+		}
+		result.Start = duration.subtractFrom(result.End, converter.Location())
+	default:
+		if result.Start, err = parseTimestamp(startValue, converter, isGasDay); err != nil {
+			return mako_time_converter.Interval{}, err
+		}
+		if result.End, err = parseTimestamp(endValue, converter, isGasDay); err != nil {
+			return mako_time_converter.Interval{}, err
+		}
+	}
+	if err = result.Validate(); err != nil {
+		return mako_time_converter.Interval{}, err
+	}
+	return result, nil
+}
+
+// ParseAndConvert parses the ISO 8601 interval (see ParseInterval) and converts it using GasTagConverter.ConvertInterval. Dates are understood as the start of German Gastage iff the configuration.Source is Gas-Tag aware.
+func ParseAndConvert(value string, converter mako_time_converter.GasTagConverter, configuration mako_time_converter.DateTimeConversionConfiguration) (mako_time_converter.Interval, error) {
+	source := configuration.Source
+	isGasDay := source.IsGas && source.IsGasTagAware != nil && *source.IsGasTagAware
+	interval, err := ParseInterval(value, converter, isGasDay)
+	if err != nil {
+		return mako_time_converter.Interval{}, err
+	}
+	return converter.ConvertInterval(interval, configuration)
+}
+
+// FormatInterval formats the interval as "start/end" with RFC 3339 UTC date times, e.g. "2023-01-01T05:00:00Z/2023-02-01T05:00:00Z"
+func FormatInterval(interval mako_time_converter.Interval) string {
+	return interval.Start.UTC().Format(time.RFC3339Nano) + "/" + interval.End.UTC().Format(time.RFC3339Nano)
+}
+
+// FormatDateInterval formats the interval as "start/end" with dates, e.g. "2023-01-01/2023-02-01". Start and end have to be starts of German days (German midnight if isGasDay is false, German 6am if isGasDay is true); otherwise an error is returned because information would be lost.
+func FormatDateInterval(interval mako_time_converter.Interval, converter mako_time_converter.GasTagConverter, isGasDay bool) (string, error) {
+	formatDate := func(timestamp time.Time) (string, error) {
+		isDayStart := converter.IsGermanMidnight(timestamp)
+		if isGasDay {
+			isDayStart = converter.IsGerman6Am(timestamp)
+		}
+		if !isDayStart {
+			return "", fmt.Errorf("%w: %v is not the start of a German day and cannot be formatted as date", ErrInvalidInterval, timestamp)
+		}
+		return timestamp.In(converter.Location()).Format(dateLayout), nil
+	}
+	start, err := formatDate(interval.Start)
+	if err != nil {
+		return "", err
+	}
+	end, err := formatDate(interval.End)
+	if err != nil {
+		return "", err
+	}
+	return start + "/" + end, nil
+}
@@ -0,0 +1,117 @@
+package iso8601_test
+
+import (
+	"errors"
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/mako_time_converter"
+	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
+	"github.com/hochfrequenz/mako_time_converter/iso8601"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type Suite struct {
+	suite.Suite
+	converter mako_time_converter.GasTagConverter
+}
+
+// SetupSuite sets up the tests
+func (s *Suite) SetupSuite() {
+	s.converter = mako_time_converter.NewGasTagConverter("Europe/Berlin")
+}
+
+func TestInit(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+func pointer[T any](b T) *T {
+	return &b
+}
+
+func (s *Suite) Test_ParseDuration() {
+	durations := map[string]iso8601.Duration{
+		"P1M":        {Months: 1},
+		"P1Y":        {Years: 1},
+		"P2W":        {Days: 14},
+		"P1Y2M3DT4H": {Years: 1, Months: 2, Days: 3, Time: 4 * time.Hour},
+		"PT15M":      {Time: 15 * time.Minute},
+		"PT1.5S":     {Time: 1500 * time.Millisecond},
+	}
+	for value, expected := range durations {
+		actual, err := iso8601.ParseDuration(value)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), actual, is.EqualTo(expected))
+	}
+	then.AssertThat(s.T(), iso8601.Duration{Years: 1, Months: 2, Days: 3, Time: 4*time.Hour + 5*time.Minute + 1500*time.Millisecond}.String(), is.EqualTo("P1Y2M3DT4H5M1.5S"))
+	then.AssertThat(s.T(), iso8601.Duration{}.String(), is.EqualTo("PT0S"))
+	for _, invalid := range []string{"P", "PT", "P1DT", "1M", "P1H", "PM",
+		"P99999999999999999999D", "P9223372036854775807W", "P1W9223372036854775807D", "PT2562048H", "PT153722867281M", "PT2562047H60M", "PT9223372037S", "PT2562047H47M17S"} {
+		_, err := iso8601.ParseDuration(invalid)
+		then.AssertThat(s.T(), errors.Is(err, iso8601.ErrInvalidInterval), is.True())
+	}
+}
+
+func (s *Suite) Test_ParseInterval() {
+	type testCase struct {
+		value    string
+		isGasDay bool
+		expected mako_time_converter.Interval
+	}
+	januaryGas := mako_time_converter.Interval{Start: time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC), End: time.Date(2023, 2, 1, 5, 0, 0, 0, time.UTC)}
+	januaryStrom := mako_time_converter.Interval{Start: time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC), End: time.Date(2023, 1, 31, 23, 0, 0, 0, time.UTC)}
+	testCases := []testCase{
+		{value: "2023-01-01T05:00:00Z/2023-02-01T05:00:00Z", expected: januaryGas},
+		{value: "2023-01-01T06:00:00+01:00/2023-02-01T06:00:00+01:00", expected: januaryGas},
+		{value: "2023-01-01/2023-02-01", isGasDay: true, expected: januaryGas},
+		{value: "2023-01-01/2023-02-01", isGasDay: false, expected: januaryStrom},
+		{value: "2023-01-01/P1M", isGasDay: true, expected: januaryGas},
+		{value: "2023-01-01/P1M", isGasDay: false, expected: januaryStrom},
+		{value: "2023-01-01T05:00:00Z/P1M", expected: januaryGas},
+		{value: "P1M/2023-02-01", isGasDay: true, expected: januaryGas},
+		// a month over the DST transition starts at 05:00 UTC and ends at 04:00 UTC
+		{value: "2023-03-01/P1M", isGasDay: true, expected: mako_time_converter.Interval{Start: time.Date(2023, 3, 1, 5, 0, 0, 0, time.UTC), End: time.Date(2023, 4, 1, 4, 0, 0, 0, time.UTC)}},
+		{value: "P1D/2023-03-27", isGasDay: false, expected: mako_time_converter.Interval{Start: time.Date(2023, 3, 25, 23, 0, 0, 0, time.UTC), End: time.Date(2023, 3, 26, 22, 0, 0, 0, time.UTC)}},
+		{value: "2023-03-26T00:00:00+01:00/PT23H", expected: mako_time_converter.Interval{Start: time.Date(2023, 3, 25, 23, 0, 0, 0, time.UTC), End: time.Date(2023, 3, 26, 22, 0, 0, 0, time.UTC)}},
+	}
+	for _, tc := range testCases {
+		actual, err := iso8601.ParseInterval(tc.value, s.converter, tc.isGasDay)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), actual, is.EqualTo(tc.expected))
+	}
+}
+
+func (s *Suite) Test_ParseInterval_Invalid() {
+	for _, invalid := range []string{"2023-01-01", "P1M/P1D", "2023-01-01/P1X", "P1X/2023-01-01", "2023-13-01/P1M", "P1M/2023-13-01", "2023-13-01/2023-01-01", "2023-01-01/2023-13-01"} {
+		_, err := iso8601.ParseInterval(invalid, s.converter, false)
+		then.AssertThat(s.T(), errors.Is(err, iso8601.ErrInvalidInterval), is.True())
+	}
+	_, err := iso8601.ParseInterval("2023-02-01/2023-01-01", s.converter, false)
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidInterval), is.True())
+}
+
+func (s *Suite) Test_ParseAndConvert() {
+	configuration := mako_time_converter.DateTimeConversionConfiguration{
+		Source: mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(true), IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.EXCLUSIVE)},
+		Target: mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(false), IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.INCLUSIVE)},
+	}
+	actual, err := iso8601.ParseAndConvert("2023-01-01/P1M", s.converter, configuration)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), actual, is.EqualTo(mako_time_converter.Interval{Start: time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC), End: time.Date(2023, 1, 30, 23, 0, 0, 0, time.UTC)}))
+
+	_, err = iso8601.ParseAndConvert("2023-01-01", s.converter, configuration)
+	then.AssertThat(s.T(), errors.Is(err, iso8601.ErrInvalidInterval), is.True())
+}
+
+func (s *Suite) Test_Format() {
+	interval := mako_time_converter.Interval{Start: time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC), End: time.Date(2023, 2, 1, 5, 0, 0, 0, time.UTC)}
+	then.AssertThat(s.T(), iso8601.FormatInterval(interval), is.EqualTo("2023-01-01T05:00:00Z/2023-02-01T05:00:00Z"))
+	formatted, err := iso8601.FormatDateInterval(interval, s.converter, true)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), formatted, is.EqualTo("2023-01-01/2023-02-01"))
+	_, err = iso8601.FormatDateInterval(interval, s.converter, false) // 6am is not the start of a Stromtag
+	then.AssertThat(s.T(), errors.Is(err, iso8601.ErrInvalidInterval), is.True())
+	_, err = iso8601.FormatDateInterval(mako_time_converter.Interval{Start: interval.Start, End: interval.End.Add(time.Minute)}, s.converter, true)
+	then.AssertThat(s.T(), errors.Is(err, iso8601.ErrInvalidInterval), is.True())
+}
@@ -0,0 +1,81 @@
+package mako_time_converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// ProfileProvider provides the ConversionProfile between the JSON representation of a timestamp (DateTimeConversionConfiguration.Source) and the timestamp in Go (DateTimeConversionConfiguration.Target).
+// The zero value of the type is used, so implementations have to be non-pointer types, usually empty structs, e.g.
+//
+//	type PartnerContractEnd struct{}
+//	func (PartnerContractEnd) Profile() ConversionProfile { return partnerContractEndProfile }
+type ProfileProvider interface {
+	// Profile returns the profile that converts from JSON (Source) to Go (Target). The inverted configuration is used for the encoding.
+	// If Configuration.Source.StripTime is set, the timestamp is encoded as date-only string, e.g. "2023-10-31".
+	Profile() ConversionProfile
+}
+
+// Converted is a time.Time that is converted with the ConversionProfile of P when it is decoded from JSON and with the inverted configuration when it is encoded to JSON.
+// The JSON representation is an RFC 3339 string (e.g. "2023-10-31T23:00:00Z") or a date-only string (e.g. "2023-10-31"), which is understood as German midnight of that date; the zero Time is represented by null.
+type Converted[P ProfileProvider] struct {
+	// Time is the timestamp as it is understood in Go (DateTimeConversionConfiguration.Target of the profile)
+	Time time.Time
+}
+
+// profileOf returns the ConversionProfile of the zero value of P or ErrIncompleteProfile if P is a pointer or interface type (whose zero value is nil)
+func profileOf[P ProfileProvider]() (ConversionProfile, error) {
+	providerType := reflect.TypeFor[P]()
+	if kind := providerType.Kind(); kind == reflect.Pointer || kind == reflect.Interface {
+		return ConversionProfile{}, fmt.Errorf("%w: the profile provider %v must not be a pointer or interface type", ErrIncompleteProfile, providerType)
+	}
+	var provider P
+	return provider.Profile(), nil
+}
+
+// MarshalJSON converts the Time with the inverted configuration of the profile and returns it as JSON string
+func (c Converted[P]) MarshalJSON() ([]byte, error) {
+	if c.Time.IsZero() {
+		return []byte("null"), nil
+	}
+	profile, err := profileOf[P]()
+	if err != nil {
+		return nil, err
+	}
+	result, err := profile.encode(c.Time)
+	if err != nil {
+		return nil, err
+	}
+	if profile.isDateOnly() {
+		return json.Marshal(result.Format(time.DateOnly))
+	}
+	return json.Marshal(result.Format(time.RFC3339Nano))
+}
+
+// UnmarshalJSON parses the JSON string (RFC 3339 or date-only) and converts it with the configuration of the profile
+func (c *Converted[P]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		c.Time = time.Time{}
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("a converted time has to be a JSON string, got %s: %w", data, err)
+	}
+	profile, err := profileOf[P]()
+	if err != nil {
+		return err
+	}
+	timestamp, isDate, ok := parseExternalTime(value)
+	if !ok {
+		return fmt.Errorf("'%s' is neither an RFC 3339 date time nor a date", value)
+	}
+	result, err := profile.decode(timestamp, isDate)
+	if err != nil {
+		return err
+	}
+	c.Time = result
+	return nil
+}
@@ -0,0 +1,101 @@
+package mako_time_converter_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/mako_time_converter"
+	"time"
+)
+
+var jsonTestConverter = getBerlinConverter()
+
+// partnerContractEnd is the profile of a partner that sends inclusive, gas day unaware dates
+type partnerContractEnd struct{}
+
+func (partnerContractEnd) Profile() mako_time_converter.ConversionProfile {
+	return mako_time_converter.ConversionProfile{Converter: jsonTestConverter, Configuration: mako_time_converter.DateTimeConversionConfiguration{Source: mako_time_converter.InclusiveDateOnlyGas(), Target: mako_time_converter.MaKoStandardGas()}}
+}
+
+// legacyGasEnd is the profile of a partner that sends exclusive timestamps which are not gas day aware
+type legacyGasEnd struct{}
+
+func (legacyGasEnd) Profile() mako_time_converter.ConversionProfile {
+	return mako_time_converter.ConversionProfile{Converter: jsonTestConverter, Configuration: mako_time_converter.DateTimeConversionConfiguration{Source: mako_time_converter.LegacyMidnightGas(), Target: mako_time_converter.MaKoStandardGas()}}
+}
+
+type partnerContract struct {
+	End       mako_time_converter.Converted[partnerContractEnd]  `json:"end"`
+	LegacyEnd mako_time_converter.Converted[legacyGasEnd]        `json:"legacyEnd"`
+	Optional  *mako_time_converter.Converted[partnerContractEnd] `json:"optional,omitempty"`
+}
+
+func (s *Suite) Test_Converted_JSON() {
+	var contract partnerContract
+	err := json.Unmarshal([]byte(`{"end":"2023-10-31","legacyEnd":"2023-10-31T23:00:00Z"}`), &contract)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), contract.End.Time, is.EqualTo(time.Date(2023, 11, 1, 5, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), contract.LegacyEnd.Time, is.EqualTo(time.Date(2023, 11, 1, 5, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), contract.Optional == nil, is.True())
+
+	jsonBytes, err := json.Marshal(contract)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), string(jsonBytes), is.EqualTo(`{"end":"2023-10-31","legacyEnd":"2023-10-31T23:00:00Z"}`))
+}
+
+func (s *Suite) Test_Converted_JSON_Accepts_Both_Encodings() {
+	var fromDateTime, fromDate mako_time_converter.Converted[partnerContractEnd]
+	err := json.Unmarshal([]byte(`"2023-10-30T23:00:00Z"`), &fromDateTime)
+	then.AssertThat(s.T(), err, is.Nil())
+	err = json.Unmarshal([]byte(`"2023-10-31"`), &fromDate)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), fromDateTime.Time, is.EqualTo(fromDate.Time))
+}
+
+func (s *Suite) Test_Converted_JSON_Null_And_Errors() {
+	converted := mako_time_converter.Converted[partnerContractEnd]{Time: time.Now()}
+	err := json.Unmarshal([]byte(`null`), &converted)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), converted.Time.IsZero(), is.True())
+	jsonBytes, err := json.Marshal(converted)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), string(jsonBytes), is.EqualTo("null"))
+
+	for _, invalidJSON := range []string{`42`, `"yesterday"`} {
+		err = json.Unmarshal([]byte(invalidJSON), &converted)
+		then.AssertThat(s.T(), err, is.Not(is.Nil()))
+	}
+	err = json.Unmarshal([]byte(`"2023-10-31"`), &mako_time_converter.Converted[invalidProfile]{})
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+	_, err = json.Marshal(mako_time_converter.Converted[invalidProfile]{Time: time.Now()})
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+}
+
+type invalidProfile struct{}
+
+func (invalidProfile) Profile() mako_time_converter.ConversionProfile {
+	return mako_time_converter.ConversionProfile{Converter: jsonTestConverter, Configuration: mako_time_converter.DateTimeConversionConfiguration{Source: mako_time_converter.MaKoStandardGas(), Target: mako_time_converter.MaKoStandardStrom()}}
+}
+
+// incompleteProfile has no converter
+type incompleteProfile struct{}
+
+func (incompleteProfile) Profile() mako_time_converter.ConversionProfile {
+	return mako_time_converter.ConversionProfile{}
+}
+
+func (s *Suite) Test_Converted_JSON_Incomplete_Profiles() {
+	err := json.Unmarshal([]byte(`"2023-10-31"`), &mako_time_converter.Converted[incompleteProfile]{})
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrIncompleteProfile), is.True())
+	_, err = json.Marshal(mako_time_converter.Converted[incompleteProfile]{Time: time.Now()})
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrIncompleteProfile), is.True())
+
+	// the zero value of a pointer type is nil, so it must not be used as profile provider
+	err = json.Unmarshal([]byte(`"2023-10-31"`), &mako_time_converter.Converted[*partnerContractEnd]{})
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrIncompleteProfile), is.True())
+	_, err = json.Marshal(mako_time_converter.Converted[*partnerContractEnd]{Time: time.Now()})
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrIncompleteProfile), is.True())
+	_, err = json.Marshal(mako_time_converter.Converted[mako_time_converter.ProfileProvider]{Time: time.Now()})
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrIncompleteProfile), is.True())
+}
@@ -0,0 +1,61 @@
+package mako_time_converter
+
+import (
+	"fmt"
+	"time"
+)
+
+// MarketDefinition describes the days of an energy market: the location in which calendar days (and the Stromtag) start at local midnight, and the time at which a gas day starts.
+// The German market (see GermanMarket) is the default of all converters that are not explicitly created for a market.
+type MarketDefinition struct {
+	// Name is an identifier of the market, e.g. "DE"
+	Name string `json:"name"`
+	// ZoneName is the name of the timezone of the market, e.g. "Europe/Berlin"
+	ZoneName string `json:"zoneName" validate:"required"`
+	// GasDayStartHour is the hour at which a gas day starts, e.g. 6 for German 6am
+	GasDayStartHour int `json:"gasDayStartHour" validate:"min=0,max=23"`
+	// GasDayStartMinute is the minute at which a gas day starts (usually 0)
+	GasDayStartMinute int `json:"gasDayStartMinute" validate:"min=0,max=59"`
+	// GasDayStartUTCOffset is an optional fixed UTC offset in which the gas day start is expressed, e.g. 0 for "05:00 UTC year-round". If nil, the gas day starts at the same local time (of the ZoneName) all year, e.g. 06:00 CET and CEST.
+	GasDayStartUTCOffset *time.Duration `json:"gasDayStartUtcOffset,omitempty" validate:"omitempty,min=-14h,max=14h"`
+}
+
+// GermanMarket is the German market: days start at midnight German local time, gas days start at 6am German local time
+func GermanMarket() MarketDefinition {
+	return MarketDefinition{Name: "DE", ZoneName: "Europe/Berlin", GasDayStartHour: 6}
+}
+
+// AustrianMarket is the Austrian market: days start at midnight Austrian local time, gas days start at 6am Austrian local time
+func AustrianMarket() MarketDefinition {
+	return MarketDefinition{Name: "AT", ZoneName: "Europe/Vienna", GasDayStartHour: 6}
+}
+
+// DutchMarket is the Dutch market: days start at midnight Dutch local time, gas days start at 6am Dutch local time
+func DutchMarket() MarketDefinition {
+	return MarketDefinition{Name: "NL", ZoneName: "Europe/Amsterdam", GasDayStartHour: 6}
+}
+
+// gasDayLocation returns the location in which the start of the gas day is expressed
+func (m MarketDefinition) gasDayLocation(location *time.Location) *time.Location {
+	if m.GasDayStartUTCOffset == nil {
+		return location
+	}
+	return time.FixedZone(fmt.Sprintf("%s gas day", m.Name), int(m.GasDayStartUTCOffset.Seconds()))
+}
+
+// NewGasTagConverterForMarket returns a GasTagConverter for the given market. It returns an error that wraps ErrInvalidMarketDefinition if the market is invalid and an error that wraps ErrTimezoneDataMissing if the timezone data of the market are not available.
+// All methods that are named after German times (e.g. IsGerman6Am) refer to the local times and the gas day start of the market.
+func NewGasTagConverterForMarket(market MarketDefinition) (GasTagConverter, error) {
+	if err := configurationValidator.Struct(market); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMarketDefinition, err)
+	}
+	location, err := loadLocation(market.ZoneName)
+	if err != nil {
+		return nil, err
+	}
+	return newLocationBasedGasTagConverter(location, market), nil
+}
+
+func (l locationBasedGasTagConverter) Market() MarketDefinition {
+	return l.market
+}
@@ -0,0 +1,90 @@
+package mako_time_converter_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/mako_time_converter"
+	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
+	"time"
+)
+
+// utcGasDayMarket is a market whose gas days start at 05:00 UTC year-round (instead of 06:00 German local time)
+func utcGasDayMarket() mako_time_converter.MarketDefinition {
+	return mako_time_converter.MarketDefinition{Name: "UTC5", ZoneName: "Europe/Berlin", GasDayStartHour: 5, GasDayStartUTCOffset: pointer(time.Duration(0))}
+}
+
+func (s *Suite) Test_Default_Market_Is_German() {
+	then.AssertThat(s.T(), getBerlinConverter().Market(), is.EqualTo(mako_time_converter.GermanMarket()))
+	converter, err := mako_time_converter.NewGasTagConverterForMarket(mako_time_converter.GermanMarket())
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), converter.IsGerman6Am(time.Date(2023, 7, 1, 4, 0, 0, 0, time.UTC)), is.True())
+	then.AssertThat(s.T(), converter.Location().String(), is.EqualTo("Europe/Berlin"))
+}
+
+func (s *Suite) Test_Other_Markets() {
+	for _, market := range []mako_time_converter.MarketDefinition{mako_time_converter.AustrianMarket(), mako_time_converter.DutchMarket()} {
+		converter, err := mako_time_converter.NewGasTagConverterForMarket(market)
+		then.AssertThat(s.T(), err, is.Nil())
+		boundaries := mako_time_converter.NewBoundaries(converter)
+		then.AssertThat(s.T(), converter.Market(), is.EqualTo(market))
+		then.AssertThat(s.T(), boundaries.GasDayStart(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC)))
+	}
+}
+
+func (s *Suite) Test_Market_With_Fixed_UTC_Offset() {
+	converter, err := mako_time_converter.NewGasTagConverterForMarket(utcGasDayMarket())
+	then.AssertThat(s.T(), err, is.Nil())
+	boundaries := mako_time_converter.NewBoundaries(converter)
+	// in summer 05:00 UTC is 07:00 German local time
+	then.AssertThat(s.T(), converter.IsGerman6Am(time.Date(2023, 7, 1, 5, 0, 0, 0, time.UTC)), is.True())
+	then.AssertThat(s.T(), converter.IsGerman6Am(time.Date(2023, 7, 1, 4, 0, 0, 0, time.UTC)), is.False())
+	then.AssertThat(s.T(), boundaries.GasDayStart(time.Date(2023, 7, 1, 4, 30, 0, 0, time.UTC)), is.EqualTo(time.Date(2023, 6, 30, 5, 0, 0, 0, time.UTC)))
+	// the gas day that contains the DST transition is 24h long
+	then.AssertThat(s.T(), boundaries.GasDay(time.Date(2023, 3, 26, 10, 0, 0, 0, time.UTC)), is.EqualTo(mako_time_converter.Interval{Start: time.Date(2023, 3, 26, 5, 0, 0, 0, time.UTC), End: time.Date(2023, 3, 27, 5, 0, 0, 0, time.UTC)}))
+	then.AssertThat(s.T(), boundaries.GasDaysBetween(time.Date(2023, 3, 1, 5, 0, 0, 0, time.UTC), time.Date(2023, 4, 1, 5, 0, 0, 0, time.UTC)), is.EqualTo(31))
+	then.AssertThat(s.T(), boundaries.StartOfGasMonth(time.Date(2023, 7, 15, 0, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2023, 7, 1, 5, 0, 0, 0, time.UTC)))
+	// Stromtage still start at German midnight
+	then.AssertThat(s.T(), boundaries.StromDayStart(time.Date(2023, 7, 1, 5, 0, 0, 0, time.UTC)), is.EqualTo(time.Date(2023, 6, 30, 22, 0, 0, 0, time.UTC)))
+
+	midnight, err := converter.Convert6AamToMidnight(time.Date(2023, 7, 2, 5, 0, 0, 0, time.UTC))
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), midnight, is.EqualTo(time.Date(2023, 7, 1, 22, 0, 0, 0, time.UTC)))
+	gasDayStart, err := converter.ConvertMidnightTo6Am(midnight)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), gasDayStart, is.EqualTo(time.Date(2023, 7, 2, 5, 0, 0, 0, time.UTC)))
+
+	// an inclusive gas end date is shifted by one gas day, even across the DST transition
+	exclusiveEnd, err := converter.Convert(time.Date(2023, 3, 25, 5, 0, 0, 0, time.UTC), mako_time_converter.DateTimeConversionConfiguration{
+		Source: mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(true), IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.INCLUSIVE)},
+		Target: mako_time_converter.MaKoStandardGas(),
+	})
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), exclusiveEnd, is.EqualTo(time.Date(2023, 3, 26, 5, 0, 0, 0, time.UTC)))
+}
+
+func (s *Suite) Test_Invalid_Market_Definitions() {
+	invalidMarkets := []mako_time_converter.MarketDefinition{
+		{Name: "no zone", GasDayStartHour: 6},
+		{Name: "hour", ZoneName: "Europe/Berlin", GasDayStartHour: 24},
+		{Name: "minute", ZoneName: "Europe/Berlin", GasDayStartMinute: -1},
+		{Name: "offset", ZoneName: "Europe/Berlin", GasDayStartUTCOffset: pointer(15 * time.Hour)},
+	}
+	for _, market := range invalidMarkets {
+		converter, err := mako_time_converter.NewGasTagConverterForMarket(market)
+		then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidMarketDefinition), is.True())
+		then.AssertThat(s.T(), converter == nil, is.True())
+	}
+	_, err := mako_time_converter.NewGasTagConverterForMarket(mako_time_converter.MarketDefinition{ZoneName: "Europe/Atlantis"})
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrTimezoneDataMissing), is.True())
+}
+
+func (s *Suite) Test_Market_Definition_Serialization() {
+	jsonBytes, err := json.Marshal(utcGasDayMarket())
+	then.AssertThat(s.T(), err, is.Nil())
+	var deserializedMarket mako_time_converter.MarketDefinition
+	err = json.Unmarshal(jsonBytes, &deserializedMarket)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), deserializedMarket, is.EqualTo(utcGasDayMarket()))
+}
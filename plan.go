@@ -0,0 +1,213 @@
+package mako_time_converter
+
+import (
+	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
+	"time"
+)
+
+// gasTagShift describes if and how a gas datetime has to be shifted between German 6am and German midnight
+type gasTagShift int
+
+const (
+	// noGasTagShift means that source and target have the same gas tag awareness (or are not gas at all)
+	noGasTagShift gasTagShift = iota
+	// gasTagToMidnight means that German 6am is converted to German midnight
+	gasTagToMidnight
+	// midnightToGasTag means that German midnight is converted to German 6am
+	midnightToGasTag
+)
+
+// A ConversionPlan is a DateTimeConversionConfiguration that has been validated and resolved by GasTagConverter.Compile.
+// It is immutable and safe for concurrent use, so it can be created once (e.g. per interface partner) and then be applied to many timestamps.
+// The zero value is not usable; always use GasTagConverter.Compile to create a ConversionPlan.
+type ConversionPlan struct {
+	converter locationBasedGasTagConverter
+	// configuration is the configuration from which the plan has been compiled
+	configuration DateTimeConversionConfiguration
+	// stripSource is true if the time shall be stripped before the conversion
+	stripSource bool
+	// identity is true if source and target are the same, so that no conversion is needed
+	identity    bool
+	gasTagShift gasTagShift
+	// toExclusive converts the source end date to an exclusive end date (before the gasTagShift)
+	toExclusive endDateAdjustment
+	// fromExclusive converts the exclusive end date to the target end date kind (after the gasTagShift)
+	fromExclusive endDateAdjustment
+	// stripTarget is true if the time shall be stripped after the conversion
+	stripTarget bool
+}
+
+func (l locationBasedGasTagConverter) Compile(configuration DateTimeConversionConfiguration) (ConversionPlan, error) {
+	plan, err := compile(configuration)
+	if err != nil {
+		return ConversionPlan{}, err
+	}
+	plan.converter = l
+	return plan, nil
+}
+
+// compile validates the configuration and resolves the steps of the conversion. The returned plan has no converter yet.
+func compile(configuration DateTimeConversionConfiguration) (ConversionPlan, error) {
+	err := validateConfiguration(configuration)
+	if err != nil {
+		return ConversionPlan{}, err
+	}
+	source := configuration.Source
+	target := configuration.Target
+	plan := ConversionPlan{
+		configuration: configuration,
+		stripSource:   source.StripTime,
+		stripTarget:   target.StripTime,
+	}
+	sourceIsGasTagAware := source.IsGas && *source.IsGasTagAware
+	targetIsGasTagAware := target.IsGas && *target.IsGasTagAware
+	sourceEndDateTimeKind, targetEndDateTimeKind := resolveEndDateTimeKind(source), resolveEndDateTimeKind(target)
+	if source.IsEndDate == target.IsEndDate && sourceIsGasTagAware == targetIsGasTagAware && sourceEndDateTimeKind == targetEndDateTimeKind && source.StripTime == target.StripTime {
+		// both are the same, no conversion needed
+		plan.identity = true
+		return plan, nil
+	}
+	if source.IsGas { // this implies that the target is also gas, because otherwise the configuration would be invalid
+		if sourceIsGasTagAware && !targetIsGasTagAware {
+			plan.gasTagShift = gasTagToMidnight
+		}
+		if !sourceIsGasTagAware && targetIsGasTagAware {
+			plan.gasTagShift = midnightToGasTag
+		}
+	}
+	// the gas day shift of end dates like 05:59:59 requires the conversion to exclusive end dates, even if source and target are of the same kind
+	timeOfDayEndDateNeedsGasTagShift := plan.gasTagShift != noGasTagShift && source.hasTimeOfDayEndDate()
+	if source.IsEndDate && target.IsEndDate && (sourceEndDateTimeKind != targetEndDateTimeKind || timeOfDayEndDateNeedsGasTagShift) {
+		plan.toExclusive = exclusiveEndDateAdjustment(sourceEndDateTimeKind)
+		plan.toExclusive.inGasDays = sourceIsGasTagAware
+		plan.fromExclusive = exclusiveEndDateAdjustment(targetEndDateTimeKind).inverse()
+		plan.fromExclusive.inGasDays = targetIsGasTagAware
+	}
+	return plan, nil
+}
+
+// endDateAdjustment is a number of German days and a duration that are added to convert between two kinds of end dates
+type endDateAdjustment struct {
+	days     int
+	duration time.Duration
+	// inGasDays is true if the days are added in the location of the gas day start (see MarketDefinition.GasDayStartUTCOffset) instead of the local time
+	inGasDays bool
+}
+
+// exclusiveEndDateAdjustment returns the adjustment that converts an end date of the given kind to an exclusive end date
+func exclusiveEndDateAdjustment(kind enddatetimekind.EndDateTimeKind) endDateAdjustment {
+	switch kind {
+	case enddatetimekind.INCLUSIVE:
+		return endDateAdjustment{days: 1}
+	case enddatetimekind.INCLUSIVE_LAST_SECOND:
+		return endDateAdjustment{duration: time.Second}
+	case enddatetimekind.INCLUSIVE_LAST_MILLISECOND:
+		return endDateAdjustment{duration: time.Millisecond}
+	case enddatetimekind.INCLUSIVE_LAST_QUARTER_HOUR:
+		return endDateAdjustment{duration: QuarterHour}
+	case enddatetimekind.INCLUSIVE_LAST_HOUR:
+		return endDateAdjustment{duration: time.Hour}
+	}
+	return endDateAdjustment{} // exclusive
+}
+
+func (a endDateAdjustment) inverse() endDateAdjustment {
+	return endDateAdjustment{days: -a.days, duration: -a.duration, inGasDays: a.inGasDays}
+}
+
+func (a endDateAdjustment) isZero() bool {
+	return a.days == 0 && a.duration == 0
+}
+
+// apply adds the German days (keeping the local time of day) and the duration to the timestamp
+func (a endDateAdjustment) apply(l locationBasedGasTagConverter, timestamp time.Time) time.Time {
+	result := timestamp
+	if a.days != 0 && a.inGasDays {
+		result = l.addGasDays(result, a.days)
+	} else if a.days != 0 {
+		result = l.addGermanDays(result, a.days)
+	}
+	return result.Add(a.duration)
+}
+
+// resolveEndDateTimeKind returns the EndDateTimeKind of an end date configuration or 0 if the configuration does not describe an end date
+func resolveEndDateTimeKind(configuration DateTimeConfiguration) enddatetimekind.EndDateTimeKind {
+	if !configuration.IsEndDate {
+		return 0
+	}
+	return *configuration.EndDateTimeKind
+}
+
+// Configuration returns the configuration from which the plan has been compiled
+func (p ConversionPlan) Configuration() DateTimeConversionConfiguration {
+	return p.configuration
+}
+
+// Apply converts the given timestamp from the Source to the Target of the configuration from which the plan has been compiled. It returns the same result as GasTagConverter.Convert but does not validate the configuration again.
+func (p ConversionPlan) Apply(timestamp time.Time) (time.Time, error) {
+	return p.apply(timestamp, nil)
+}
+
+// apply converts the timestamp. If trace is not nil, all (applied and skipped) steps are recorded in the trace.
+func (p ConversionPlan) apply(timestamp time.Time, trace *ConversionTrace) (time.Time, error) {
+	var err error
+	l := p.converter
+	result := timestamp
+	if p.stripSource {
+		result = l.StripTime(result)
+		trace.record(StepStripSource, true, "Source.StripTime is set", result)
+	} else {
+		trace.record(StepStripSource, false, "Source.StripTime is not set", result)
+	}
+	if p.identity {
+		trace.record(StepToExclusive, false, "source and target are the same", result)
+		trace.record(StepGasTagShift, false, "source and target are the same", result)
+		trace.record(StepFromExclusive, false, "source and target are the same", result)
+		trace.record(StepStripTarget, false, "source and target are the same", result)
+		return result.UTC(), nil
+	}
+	if p.toExclusive.isZero() {
+		trace.record(StepToExclusive, false, "source and target are not end dates of different kinds", result)
+	} else {
+		result = p.toExclusive.apply(l, result)
+		trace.record(StepToExclusive, true, "source end date converted to exclusive end date", result)
+	}
+	switch p.gasTagShift {
+	case gasTagToMidnight:
+		// convert from gas-tag to non-gas-tag
+		if l.IsGerman6Am(result) {
+			result, err = l.Convert6AamToMidnight(result)
+			if err != nil { // the error won't happen because Convert6AmToMidnight only returns an error if the datetime is not 6Am (which we checked before)
+				return time.Time{}, err
+			}
+			trace.record(StepGasTagShift, true, "German 6am converted to German midnight", result)
+		} else {
+			trace.record(StepGasTagShift, false, "not German 6am, gas day shift skipped", result)
+		}
+	case midnightToGasTag:
+		if l.IsGermanMidnight(result) {
+			result, err = l.ConvertMidnightTo6Am(result)
+			if err != nil { //  the error won't happen because ConvertMidnightTo6Am only returns an error if the datetime is not midnight (which we checked before)
+				return time.Time{}, err
+			}
+			trace.record(StepGasTagShift, true, "German midnight converted to German 6am", result)
+		} else {
+			trace.record(StepGasTagShift, false, "not German midnight, gas day shift skipped", result)
+		}
+	default:
+		trace.record(StepGasTagShift, false, "source and target have the same Gas-Tag awareness", result)
+	}
+	if p.fromExclusive.isZero() {
+		trace.record(StepFromExclusive, false, "source and target are not end dates of different kinds", result)
+	} else {
+		result = p.fromExclusive.apply(l, result)
+		trace.record(StepFromExclusive, true, "exclusive end date converted to target end date", result)
+	}
+	if p.stripTarget {
+		result = l.StripTime(result)
+		trace.record(StepStripTarget, true, "Target.StripTime is set", result)
+	} else {
+		trace.record(StepStripTarget, false, "Target.StripTime is not set", result)
+	}
+	return result.UTC(), nil
+}
@@ -0,0 +1,99 @@
+package mako_time_converter_test
+
+import (
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/mako_time_converter"
+	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
+	"sync"
+	"testing"
+	"time"
+)
+
+func (s *Suite) Test_Compiled_Plan_Equals_Convert() {
+	configs := []mako_time_converter.DateTimeConversionConfiguration{
+		inclusiveToExclusiveGasConversion,
+		inclusiveToExclusiveGasConversion.Invert(),
+		{
+			Source: mako_time_converter.DateTimeConfiguration{IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.INCLUSIVE), StripTime: true},
+			Target: mako_time_converter.DateTimeConfiguration{IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.EXCLUSIVE)},
+		},
+		{
+			Source: mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(false)},
+			Target: mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(true), StripTime: true},
+		},
+		{
+			Source: mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(true)},
+			Target: mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(true)},
+		},
+	}
+	inputs := []time.Time{
+		time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC),
+		time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC),
+		time.Date(2023, 3, 26, 4, 0, 0, 0, time.UTC),
+		time.Date(2023, 10, 28, 22, 0, 0, 0, time.UTC),
+		time.Date(2023, 5, 31, 12, 34, 56, 0, time.UTC),
+	}
+	converter := getBerlinConverter()
+	for _, config := range configs {
+		plan, err := converter.Compile(config)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), plan.Configuration(), is.EqualTo(config))
+		for _, input := range inputs {
+			expected, err := converter.Convert(input, config)
+			then.AssertThat(s.T(), err, is.Nil())
+			actual, err := plan.Apply(input)
+			then.AssertThat(s.T(), err, is.Nil())
+			then.AssertThat(s.T(), actual, is.EqualTo(expected))
+		}
+	}
+}
+
+func (s *Suite) Test_Compile_Rejects_Invalid_Configuration() {
+	invalidConfig := mako_time_converter.DateTimeConversionConfiguration{
+		Source: mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(true)},
+		Target: mako_time_converter.DateTimeConfiguration{IsGas: false},
+	}
+	_, err := getBerlinConverter().Compile(invalidConfig)
+	then.AssertThat(s.T(), err, is.Not(is.Nil()))
+}
+
+func (s *Suite) Test_Compiled_Plan_Is_Goroutine_Safe() {
+	plan, err := getBerlinConverter().Compile(inclusiveToExclusiveGasConversion)
+	then.AssertThat(s.T(), err, is.Nil())
+	input := time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC)
+	expected := time.Date(2023, 1, 2, 5, 0, 0, 0, time.UTC)
+	var waitGroup sync.WaitGroup
+	results := make([]time.Time, 16)
+	for index := range results {
+		waitGroup.Add(1)
+		go func(index int) {
+			defer waitGroup.Done()
+			results[index], _ = plan.Apply(input)
+		}(index)
+	}
+	waitGroup.Wait()
+	for _, result := range results {
+		then.AssertThat(s.T(), result, is.EqualTo(expected))
+	}
+}
+
+func (s *Suite) Test_Compiled_Plan_Does_Not_Allocate() {
+	plan, err := getBerlinConverter().Compile(inclusiveToExclusiveGasConversion)
+	then.AssertThat(s.T(), err, is.Nil())
+	input := time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC)
+	allocations := testing.AllocsPerRun(100, func() {
+		_, _ = plan.Apply(input)
+	})
+	then.AssertThat(s.T(), allocations, is.EqualTo(0.0))
+}
+
+func BenchmarkConversionPlan_Apply(b *testing.B) {
+	plan, _ := getBerlinConverter().Compile(inclusiveToExclusiveGasConversion)
+	timestamps := benchmarkTimestamps(b.N)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for index := 0; index < b.N; index++ {
+		_, _ = plan.Apply(timestamps[index])
+	}
+}
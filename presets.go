@@ -0,0 +1,190 @@
+package mako_time_converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
+	"sort"
+	"sync"
+)
+
+// The presets describe how common systems understand end dates (IsEndDate is true). The start dates of the same system are described by the same preset; GasTagConverter.ConvertInterval derives the start date configuration from it.
+
+// MaKoStandardGas is the date time semantics of German Marktkommunikation for Gas: aware of the German Gas-Tag (days start at 6am German local time) with exclusive end dates
+func MaKoStandardGas() DateTimeConfiguration {
+	return newPreset(true, true, enddatetimekind.EXCLUSIVE, false)
+}
+
+// MaKoStandardStrom is the date time semantics of German Marktkommunikation for Strom: days start at midnight German local time; end dates are exclusive
+func MaKoStandardStrom() DateTimeConfiguration {
+	return newPreset(false, false, enddatetimekind.EXCLUSIVE, false)
+}
+
+// InclusiveDateOnlyGas describes systems that model Gas dates as dates without time (German midnight) and inclusive end dates, e.g. "2023-01-31" for the end of January
+func InclusiveDateOnlyGas() DateTimeConfiguration {
+	return newPreset(true, false, enddatetimekind.INCLUSIVE, true)
+}
+
+// LegacyMidnightGas describes systems that are unaware of the German Gas-Tag (Gas days start at midnight German local time) but use exclusive end dates
+func LegacyMidnightGas() DateTimeConfiguration {
+	return newPreset(true, false, enddatetimekind.EXCLUSIVE, false)
+}
+
+func newPreset(isGas bool, isGasTagAware bool, endDateTimeKind enddatetimekind.EndDateTimeKind, stripTime bool) DateTimeConfiguration {
+	result := DateTimeConfiguration{
+		IsEndDate:       true,
+		EndDateTimeKind: &endDateTimeKind,
+		IsGas:           isGas,
+		StripTime:       stripTime,
+	}
+	if isGas {
+		result.IsGasTagAware = &isGasTagAware
+	}
+	return result
+}
+
+// clone returns a deep copy of the configuration, so that changes of the pointer fields of the copy do not affect the original
+func (dtc DateTimeConfiguration) clone() DateTimeConfiguration {
+	result := dtc
+	if dtc.IsGasTagAware != nil {
+		isGasTagAware := *dtc.IsGasTagAware
+		result.IsGasTagAware = &isGasTagAware
+	}
+	if dtc.EndDateTimeKind != nil {
+		endDateTimeKind := *dtc.EndDateTimeKind
+		result.EndDateTimeKind = &endDateTimeKind
+	}
+	if dtc.Sparte != nil {
+		s := *dtc.Sparte
+		result.Sparte = &s
+	}
+	return result
+}
+
+// ProfileRegistry maps names of systems (e.g. "SAP_ISU_GAS") to DateTimeConfigurations (profiles) that describe how the respective system understands dates. It is safe for concurrent use.
+// Its JSON representation is an object with the profile names as keys and the profiles as values, so that profiles can be stored in configuration files.
+type ProfileRegistry struct {
+	mutex    sync.RWMutex
+	profiles map[string]DateTimeConfiguration
+}
+
+// Names of the profiles that are registered in every registry returned by NewProfileRegistry
+const (
+	// MaKoGasProfile is the name of the MaKoStandardGas profile
+	MaKoGasProfile = "MAKO_GAS"
+	// MaKoStromProfile is the name of the MaKoStandardStrom profile
+	MaKoStromProfile = "MAKO_STROM"
+	// InclusiveDateOnlyGasProfile is the name of the InclusiveDateOnlyGas profile
+	InclusiveDateOnlyGasProfile = "INCLUSIVE_DATE_ONLY_GAS"
+	// LegacyMidnightGasProfile is the name of the LegacyMidnightGas profile
+	LegacyMidnightGasProfile = "LEGACY_MIDNIGHT_GAS"
+)
+
+// NewProfileRegistry returns a registry which contains the presets (MaKoGasProfile, MaKoStromProfile, InclusiveDateOnlyGasProfile, LegacyMidnightGasProfile)
+func NewProfileRegistry() *ProfileRegistry {
+	return &ProfileRegistry{profiles: map[string]DateTimeConfiguration{
+		MaKoGasProfile:              MaKoStandardGas(),
+		MaKoStromProfile:            MaKoStandardStrom(),
+		InclusiveDateOnlyGasProfile: InclusiveDateOnlyGas(),
+		LegacyMidnightGasProfile:    LegacyMidnightGas(),
+	}}
+}
+
+// DefaultProfileRegistry is the registry that is used by the package level functions RegisterProfile and ConfigurationBetween
+var DefaultProfileRegistry = NewProfileRegistry()
+
+// validateProfile returns an InvalidConfigurationError if the profile is invalid on its own, i.e. if it is not even valid as source and target of the same configuration (e.g. StripTime with a time of day end date or a Sparte that does not match IsGas)
+func validateProfile(profile DateTimeConfiguration) error {
+	return validateConfiguration(DateTimeConversionConfiguration{Source: profile, Target: profile})
+}
+
+// Register adds (or replaces) the profile with the given name. It returns an InvalidConfigurationError if the profile is invalid.
+func (r *ProfileRegistry) Register(name string, profile DateTimeConfiguration) error {
+	if err := validateProfile(profile); err != nil {
+		return err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.profiles[name] = profile.clone()
+	return nil
+}
+
+// Profile returns the profile with the given name or an error that wraps ErrUnknownProfile
+func (r *ProfileRegistry) Profile(name string) (DateTimeConfiguration, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	profile, ok := r.profiles[name]
+	if !ok {
+		return DateTimeConfiguration{}, fmt.Errorf("%w: '%s'", ErrUnknownProfile, name)
+	}
+	return profile.clone(), nil
+}
+
+// Names returns the sorted names of all registered profiles
+func (r *ProfileRegistry) Names() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	names := make([]string, 0, len(r.profiles))
+	for name := range r.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ConfigurationBetween returns the configuration to convert dates of the system with the source profile to the system with the target profile, e.g. ConfigurationBetween("SAP_ISU_GAS", MaKoGasProfile). It returns an error if one of the profiles is unknown or if the profiles cannot be combined (e.g. Gas and Strom).
+func (r *ProfileRegistry) ConfigurationBetween(source, target string) (DateTimeConversionConfiguration, error) {
+	sourceProfile, err := r.Profile(source)
+	if err != nil {
+		return DateTimeConversionConfiguration{}, err
+	}
+	targetProfile, err := r.Profile(target)
+	if err != nil {
+		return DateTimeConversionConfiguration{}, err
+	}
+	result := DateTimeConversionConfiguration{Source: sourceProfile, Target: targetProfile}
+	if err = validateConfiguration(result); err != nil {
+		return DateTimeConversionConfiguration{}, err
+	}
+	return result, nil
+}
+
+// MarshalJSON returns the profiles as JSON object with the profile names as keys
+func (r *ProfileRegistry) MarshalJSON() ([]byte, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return json.Marshal(r.profiles)
+}
+
+// UnmarshalJSON registers all profiles of the given JSON object (see Register). Profiles which are already registered but not part of the JSON are kept.
+// If one of the profiles is invalid, an InvalidConfigurationError is returned and none of the profiles is registered.
+func (r *ProfileRegistry) UnmarshalJSON(data []byte) error {
+	var profiles map[string]DateTimeConfiguration
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return err
+	}
+	for name, profile := range profiles {
+		if err := validateProfile(profile); err != nil {
+			return fmt.Errorf("profile '%s': %w", name, err)
+		}
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.profiles == nil {
+		r.profiles = map[string]DateTimeConfiguration{}
+	}
+	for name, profile := range profiles {
+		r.profiles[name] = profile.clone()
+	}
+	return nil
+}
+
+// RegisterProfile adds (or replaces) the profile with the given name in the DefaultProfileRegistry
+func RegisterProfile(name string, profile DateTimeConfiguration) error {
+	return DefaultProfileRegistry.Register(name, profile)
+}
+
+// ConfigurationBetween returns the configuration to convert from the source profile to the target profile of the DefaultProfileRegistry (see ProfileRegistry.ConfigurationBetween)
+func ConfigurationBetween(source, target string) (DateTimeConversionConfiguration, error) {
+	return DefaultProfileRegistry.ConfigurationBetween(source, target)
+}
@@ -0,0 +1,105 @@
+package mako_time_converter_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/mako_time_converter"
+	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
+	"github.com/hochfrequenz/mako_time_converter/sparte"
+	"time"
+)
+
+func (s *Suite) Test_Presets() {
+	then.AssertThat(s.T(), mako_time_converter.MaKoStandardGas(), is.EqualTo(mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(true), IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.EXCLUSIVE)}))
+	then.AssertThat(s.T(), mako_time_converter.MaKoStandardStrom(), is.EqualTo(mako_time_converter.DateTimeConfiguration{IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.EXCLUSIVE)}))
+	then.AssertThat(s.T(), mako_time_converter.InclusiveDateOnlyGas(), is.EqualTo(mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(false), IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.INCLUSIVE), StripTime: true}))
+	then.AssertThat(s.T(), mako_time_converter.LegacyMidnightGas(), is.EqualTo(mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(false), IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.EXCLUSIVE)}))
+}
+
+func (s *Suite) Test_ProfileRegistry_ConfigurationBetween() {
+	registry := mako_time_converter.NewProfileRegistry()
+	err := registry.Register("SAP_ISU_GAS", mako_time_converter.InclusiveDateOnlyGas())
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), registry.Names(), is.EqualTo([]string{"INCLUSIVE_DATE_ONLY_GAS", "LEGACY_MIDNIGHT_GAS", "MAKO_GAS", "MAKO_STROM", "SAP_ISU_GAS"}))
+
+	configuration, err := registry.ConfigurationBetween("SAP_ISU_GAS", mako_time_converter.MaKoGasProfile)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), configuration, is.EqualTo(mako_time_converter.DateTimeConversionConfiguration{Source: mako_time_converter.InclusiveDateOnlyGas(), Target: mako_time_converter.MaKoStandardGas()}))
+	actual, err := getBerlinConverter().Convert(time.Date(2023, 1, 30, 23, 0, 0, 0, time.UTC), configuration) // 2023-01-31 inclusive
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), actual, is.EqualTo(time.Date(2023, 2, 1, 5, 0, 0, 0, time.UTC)))
+
+	_, err = registry.ConfigurationBetween("SAP_ISU_GAS", "UNKNOWN")
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrUnknownProfile), is.True())
+	_, err = registry.ConfigurationBetween("UNKNOWN", "SAP_ISU_GAS")
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrUnknownProfile), is.True())
+	_, err = registry.ConfigurationBetween(mako_time_converter.MaKoGasProfile, mako_time_converter.MaKoStromProfile)
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+}
+
+func (s *Suite) Test_ProfileRegistry_Register_Invalid_Profile() {
+	registry := mako_time_converter.NewProfileRegistry()
+	err := registry.Register("INVALID", mako_time_converter.DateTimeConfiguration{IsGas: true, IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.EXCLUSIVE)}) // IsGasTagAware is missing
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+	_, err = registry.Profile("INVALID")
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrUnknownProfile), is.True())
+
+	// profiles that violate the rules between the fields are rejected at registration, not only when they are combined
+	err = registry.Register("STRIPPED_LAST_SECOND", mako_time_converter.DateTimeConfiguration{IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.INCLUSIVE_LAST_SECOND), StripTime: true})
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+	err = registry.Register("GAS_SPARTE_WITHOUT_GAS", mako_time_converter.DateTimeConfiguration{Sparte: pointer(sparte.GAS)})
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+
+	var invalidConfiguration mako_time_converter.InvalidConfigurationError
+	err = json.Unmarshal([]byte(`{"VALID":{"isGas":false},"GAS_SPARTE_WITHOUT_GAS":{"isGas":false,"sparte":"GAS"}}`), registry)
+	then.AssertThat(s.T(), errors.As(err, &invalidConfiguration), is.True())
+	then.AssertThat(s.T(), registry.Names(), is.EqualTo(mako_time_converter.NewProfileRegistry().Names()))
+}
+
+func (s *Suite) Test_ProfileRegistry_Profiles_Are_Copies() {
+	registry := mako_time_converter.NewProfileRegistry()
+	profile, err := registry.Profile(mako_time_converter.MaKoGasProfile)
+	then.AssertThat(s.T(), err, is.Nil())
+	*profile.IsGasTagAware = false
+	profile, err = registry.Profile(mako_time_converter.MaKoGasProfile)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), *profile.IsGasTagAware, is.True())
+}
+
+func (s *Suite) Test_ProfileRegistry_Serialization() {
+	registry := mako_time_converter.NewProfileRegistry()
+	err := registry.Register("SAP_ISU_GAS", mako_time_converter.InclusiveDateOnlyGas())
+	then.AssertThat(s.T(), err, is.Nil())
+	jsonBytes, err := json.Marshal(registry)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), string(jsonBytes), is.StringContaining(`"SAP_ISU_GAS":{"isEndDate":true,"endDateTimeKind":"INCLUSIVE"`))
+
+	var deserializedRegistry mako_time_converter.ProfileRegistry
+	err = json.Unmarshal(jsonBytes, &deserializedRegistry)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), deserializedRegistry.Names(), is.EqualTo(registry.Names()))
+	profile, err := deserializedRegistry.Profile("SAP_ISU_GAS")
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), profile, is.EqualTo(mako_time_converter.InclusiveDateOnlyGas()))
+
+	err = json.Unmarshal([]byte(`{"INVALID":{"isGas":true,"isEndDate":true,"endDateTimeKind":"EXCLUSIVE"}}`), &deserializedRegistry)
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+}
+
+func (s *Suite) Test_Package_Level_ConfigurationBetween() {
+	// the DefaultProfileRegistry is only read, so that no state leaks into other tests
+	configuration, err := mako_time_converter.ConfigurationBetween(mako_time_converter.MaKoGasProfile, mako_time_converter.LegacyMidnightGasProfile)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), configuration.Target, is.EqualTo(mako_time_converter.LegacyMidnightGas()))
+	_, err = mako_time_converter.ConfigurationBetween(mako_time_converter.MaKoGasProfile, "LEGACY_GAS_SYSTEM")
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrUnknownProfile), is.True())
+
+	registry := mako_time_converter.NewProfileRegistry()
+	err = registry.Register("LEGACY_GAS_SYSTEM", mako_time_converter.LegacyMidnightGas())
+	then.AssertThat(s.T(), err, is.Nil())
+	configuration, err = registry.ConfigurationBetween(mako_time_converter.MaKoGasProfile, "LEGACY_GAS_SYSTEM")
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), configuration.Target, is.EqualTo(mako_time_converter.LegacyMidnightGas()))
+}
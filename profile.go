@@ -0,0 +1,84 @@
+package mako_time_converter
+
+import (
+	"time"
+)
+
+// ConversionProfile describes how the timestamps of an external representation (e.g. a database column or a JSON field) are converted to the timestamps that the application uses.
+// It is used by ConvertedTime (database) and Converted (JSON).
+type ConversionProfile struct {
+	// Converter is used for the conversion
+	Converter GasTagConverter
+	// Configuration converts from the external representation (Source) to the application (Target). The inverted Configuration is used to write the external representation.
+	// If Configuration.Source.StripTime is set, the external representation is understood as date-only (e.g. SQL DATE or "2023-10-31") with German local dates.
+	Configuration DateTimeConversionConfiguration
+}
+
+// NewConversionProfile returns a ConversionProfile or an InvalidConfigurationError if the configuration is invalid
+func NewConversionProfile(converter GasTagConverter, configuration DateTimeConversionConfiguration) (ConversionProfile, error) {
+	if converter == nil {
+		return ConversionProfile{}, ErrIncompleteProfile
+	}
+	if err := validateConfiguration(configuration); err != nil {
+		return ConversionProfile{}, err
+	}
+	return ConversionProfile{Converter: converter, Configuration: configuration}, nil
+}
+
+// Time returns a ConvertedTime with the given (application) timestamp, e.g. to write it to the database
+func (p ConversionProfile) Time(timestamp time.Time) ConvertedTime {
+	return ConvertedTime{Time: timestamp, Profile: p}
+}
+
+// isDateOnly returns true if the external representation contains dates without time
+func (p ConversionProfile) isDateOnly() bool {
+	return p.Configuration.Source.StripTime
+}
+
+// decode converts an external timestamp to the application. If isDate is true, only the date of the external timestamp matters and it is understood as German midnight.
+func (p ConversionProfile) decode(external time.Time, isDate bool) (time.Time, error) {
+	if p.Converter == nil {
+		return time.Time{}, ErrIncompleteProfile
+	}
+	if isDate {
+		// dates are usually returned as midnight of an arbitrary location (e.g. UTC), but it's the German local date that matters
+		year, month, day := external.Date()
+		external = time.Date(year, month, day, 0, 0, 0, 0, p.Converter.Location())
+	}
+	return p.Converter.Convert(external, p.Configuration)
+}
+
+// encode converts an application timestamp with the inverted configuration. Dates of date-only profiles are returned as UTC midnight of the German local date.
+func (p ConversionProfile) encode(timestamp time.Time) (time.Time, error) {
+	if p.Converter == nil {
+		return time.Time{}, ErrIncompleteProfile
+	}
+	result, err := p.Converter.Convert(timestamp, p.Configuration.Invert())
+	if err != nil {
+		return time.Time{}, err
+	}
+	if p.isDateOnly() {
+		year, month, day := result.In(p.Converter.Location()).Date()
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC), nil
+	}
+	return result, nil
+}
+
+// externalTimeLayouts are the layouts of textual timestamps (e.g. from database drivers or JSON). Timestamps without UTC offset are understood as UTC.
+var externalTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999",
+	time.DateOnly,
+}
+
+// parseExternalTime parses the textual representation of a timestamp. isDate is true if the value is a date without time. ok is false if none of the externalTimeLayouts matches.
+func parseExternalTime(value string) (result time.Time, isDate bool, ok bool) {
+	for _, layout := range externalTimeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, layout == time.DateOnly, true
+		}
+	}
+	return time.Time{}, false, false
+}
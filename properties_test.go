@@ -0,0 +1,197 @@
+package mako_time_converter_test
+
+import (
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/mako_time_converter"
+	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
+	"time"
+)
+
+// allDateTimeConfigurations returns all valid DateTimeConfigurations (EndDateTimeKind is only set for end dates)
+func allDateTimeConfigurations() []mako_time_converter.DateTimeConfiguration {
+	var result []mako_time_converter.DateTimeConfiguration
+	endDateTimeKinds := []*enddatetimekind.EndDateTimeKind{nil, pointer(enddatetimekind.INCLUSIVE), pointer(enddatetimekind.EXCLUSIVE), pointer(enddatetimekind.INCLUSIVE_LAST_SECOND), pointer(enddatetimekind.INCLUSIVE_LAST_MILLISECOND), pointer(enddatetimekind.INCLUSIVE_LAST_QUARTER_HOUR), pointer(enddatetimekind.INCLUSIVE_LAST_HOUR)}
+	gasTagAwareness := []*bool{nil, pointer(true), pointer(false)} // nil means Strom
+	for _, endDateTimeKind := range endDateTimeKinds {
+		for _, isGasTagAware := range gasTagAwareness {
+			for _, stripTime := range []bool{false, true} {
+				if stripTime && timeOfDayEndDateOffset(endDateTimeKind) != 0 {
+					continue // the time of end dates like 23:59:59 must not be stripped
+				}
+				result = append(result, mako_time_converter.DateTimeConfiguration{
+					IsEndDate:       endDateTimeKind != nil,
+					EndDateTimeKind: endDateTimeKind,
+					IsGas:           isGasTagAware != nil,
+					IsGasTagAware:   isGasTagAware,
+					StripTime:       stripTime,
+				})
+			}
+		}
+	}
+	return result
+}
+
+// allConversionConfigurations returns all valid DateTimeConversionConfigurations
+func allConversionConfigurations() []mako_time_converter.DateTimeConversionConfiguration {
+	var result []mako_time_converter.DateTimeConversionConfiguration
+	for _, source := range allDateTimeConfigurations() {
+		for _, target := range allDateTimeConfigurations() {
+			if source.IsGas == target.IsGas {
+				result = append(result, mako_time_converter.DateTimeConversionConfiguration{Source: source, Target: target})
+			}
+		}
+	}
+	return result
+}
+
+// timeOfDayEndDateOffset returns the duration between an end date of the given kind and the exclusive end date, if the kind depends on the time of day
+func timeOfDayEndDateOffset(kind *enddatetimekind.EndDateTimeKind) time.Duration {
+	if kind == nil {
+		return 0
+	}
+	switch *kind {
+	case enddatetimekind.INCLUSIVE_LAST_SECOND:
+		return time.Second
+	case enddatetimekind.INCLUSIVE_LAST_MILLISECOND:
+		return time.Millisecond
+	case enddatetimekind.INCLUSIVE_LAST_QUARTER_HOUR:
+		return 15 * time.Minute
+	case enddatetimekind.INCLUSIVE_LAST_HOUR:
+		return time.Hour
+	}
+	return 0
+}
+
+// sourceOffset returns the duration that is added to the source when it is converted to an exclusive end date by the configuration
+func sourceOffset(configuration mako_time_converter.DateTimeConversionConfiguration) time.Duration {
+	if !configuration.Source.IsEndDate || !configuration.Target.IsEndDate {
+		return 0
+	}
+	return timeOfDayEndDateOffset(configuration.Source.EndDateTimeKind)
+}
+
+// germanDSTTransitionDays returns the German local dates (as local midnight) on which the UTC offset of Berlin changes, from 1980 (when Germany re-introduced DST) to 2100
+func germanDSTTransitionDays() []time.Time {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	var result []time.Time
+	for day := time.Date(1980, 1, 1, 0, 0, 0, 0, berlin); day.Year() <= 2100; day = day.AddDate(0, 0, 1) {
+		_, offsetAtStart := day.Zone()
+		_, offsetAtEnd := day.AddDate(0, 0, 1).Add(-time.Nanosecond).Zone()
+		if offsetAtStart != offsetAtEnd {
+			result = append(result, day)
+		}
+	}
+	return result
+}
+
+func (s *Suite) Test_Invariant_Results_Are_UTC_And_Same_Configurations_Are_Idempotent() {
+	converter := getBerlinConverter()
+	berlin := converter.Location()
+	inputs := []time.Time{
+		time.Date(2023, 1, 1, 0, 0, 0, 0, berlin),
+		time.Date(2023, 1, 1, 6, 0, 0, 0, berlin),
+		time.Date(2023, 7, 1, 12, 34, 56, 789, berlin),
+		time.Date(2023, 7, 1, 6, 0, 0, 0, time.FixedZone("other", 3*60*60)),
+	}
+	for _, configuration := range allConversionConfigurations() {
+		for _, input := range inputs {
+			actual, err := converter.Convert(input, configuration)
+			then.AssertThat(s.T(), err, is.Nil())
+			then.AssertThat(s.T(), actual.Location(), is.EqualTo(time.UTC))
+		}
+	}
+	for _, configuration := range allDateTimeConfigurations() {
+		sameConfiguration := mako_time_converter.DateTimeConversionConfiguration{Source: configuration, Target: configuration}
+		for _, input := range inputs {
+			actual, err := converter.Convert(input, sameConfiguration)
+			then.AssertThat(s.T(), err, is.Nil())
+			expected := input.UTC()
+			if configuration.StripTime {
+				expected = converter.StripTime(input)
+			}
+			then.AssertThat(s.T(), actual, is.EqualTo(expected))
+			again, err := converter.Convert(actual, sameConfiguration)
+			then.AssertThat(s.T(), err, is.Nil())
+			then.AssertThat(s.T(), again, is.EqualTo(actual))
+		}
+	}
+}
+
+func (s *Suite) Test_Invariant_Inclusive_And_Exclusive_Differ_By_One_German_Day() {
+	converter := getBerlinConverter()
+	berlin := converter.Location()
+	days := append(germanDSTTransitionDays(), time.Date(2023, 1, 1, 0, 0, 0, 0, berlin), time.Date(2024, 2, 29, 0, 0, 0, 0, berlin))
+	for _, configuration := range allDateTimeConfigurations() {
+		if !configuration.IsEndDate || configuration.StripTime || *configuration.EndDateTimeKind != enddatetimekind.INCLUSIVE {
+			continue
+		}
+		exclusive := configuration
+		exclusive.EndDateTimeKind = pointer(enddatetimekind.EXCLUSIVE)
+		for _, day := range days {
+			for _, hour := range []int{0, 6, 12} {
+				inclusiveEnd := time.Date(day.Year(), day.Month(), day.Day(), hour, 0, 0, 0, berlin)
+				exclusiveEnd, err := converter.Convert(inclusiveEnd, mako_time_converter.DateTimeConversionConfiguration{Source: configuration, Target: exclusive})
+				then.AssertThat(s.T(), err, is.Nil())
+				then.AssertThat(s.T(), exclusiveEnd, is.EqualTo(inclusiveEnd.AddDate(0, 0, 1).UTC()))
+				then.AssertThat(s.T(), exclusiveEnd.In(berlin).Hour(), is.EqualTo(hour))
+			}
+		}
+	}
+}
+
+func (s *Suite) Test_Invariant_DST_Transitions_1980_To_2100() {
+	converter := getBerlinConverter()
+	boundaries := mako_time_converter.NewBoundaries(converter)
+	berlin := converter.Location()
+	transitionDays := germanDSTTransitionDays()
+	then.AssertThat(s.T(), len(transitionDays), is.EqualTo(2*(2100-1980+1)))
+	type compiledConfiguration struct {
+		plan, inversePlan mako_time_converter.ConversionPlan
+		sourceOffset      time.Duration
+		targetOffset      time.Duration
+	}
+	var compiledConfigurations []compiledConfiguration
+	for _, configuration := range allConversionConfigurations() {
+		plan, err := converter.Compile(configuration)
+		then.AssertThat(s.T(), err, is.Nil())
+		inversePlan, err := converter.Compile(configuration.Invert())
+		then.AssertThat(s.T(), err, is.Nil())
+		compiledConfigurations = append(compiledConfigurations, compiledConfiguration{plan: plan, inversePlan: inversePlan, sourceOffset: sourceOffset(configuration), targetOffset: sourceOffset(configuration.Invert())})
+	}
+	for _, transitionDay := range transitionDays {
+		for _, day := range []time.Time{transitionDay.AddDate(0, 0, -1), transitionDay, transitionDay.AddDate(0, 0, 1)} {
+			midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, berlin)
+			sixAm := time.Date(day.Year(), day.Month(), day.Day(), 6, 0, 0, 0, berlin)
+			then.AssertThat(s.T(), converter.IsGermanMidnight(midnight), is.True())
+			then.AssertThat(s.T(), converter.IsGerman6Am(sixAm), is.True())
+			then.AssertThat(s.T(), converter.StripTime(sixAm), is.EqualTo(midnight.UTC()))
+			converted, err := converter.ConvertMidnightTo6Am(midnight)
+			then.AssertThat(s.T(), err, is.Nil())
+			then.AssertThat(s.T(), converted, is.EqualTo(sixAm.UTC()))
+			converted, err = converter.Convert6AamToMidnight(sixAm)
+			then.AssertThat(s.T(), err, is.Nil())
+			then.AssertThat(s.T(), converted, is.EqualTo(midnight.UTC()))
+			then.AssertThat(s.T(), boundaries.GasDayStart(sixAm.Add(time.Hour)), is.EqualTo(sixAm.UTC()))
+			then.AssertThat(s.T(), boundaries.StromDayStart(midnight.Add(time.Hour)), is.EqualTo(midnight.UTC()))
+			for _, compiled := range compiledConfigurations {
+				losses := compiled.plan.Losses()
+				for _, dayStart := range []time.Time{midnight, sixAm} {
+					input := dayStart.Add(-compiled.sourceOffset)
+					result, err := compiled.plan.Apply(input)
+					then.AssertThat(s.T(), err, is.Nil())
+					then.AssertThat(s.T(), result.Location(), is.EqualTo(time.UTC))
+					reverted, err := compiled.inversePlan.Apply(result)
+					then.AssertThat(s.T(), err, is.Nil())
+					// German midnight and German 6am are never within the hour of a DST transition, so only the explicitly reported classes may be lost
+					if losses&^mako_time_converter.LossDSTTransition == 0 {
+						then.AssertThat(s.T(), reverted, is.EqualTo(input.UTC()))
+					}
+					// whatever happens, the (exclusive) result is German midnight or German 6am
+					localHour := result.Add(compiled.targetOffset).In(berlin).Hour()
+					then.AssertThat(s.T(), localHour == 0 || localHour == 6, is.True())
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package mako_time_converter
+
+import (
+	"strings"
+	"time"
+)
+
+// InformationLoss is a set of classes of input timestamps that are possibly not reproduced if they are converted with a configuration and then converted back with the inverted configuration (see DateTimeConversionConfiguration.Invert)
+type InformationLoss uint8
+
+const (
+	// LossTimeOfDay means that the time of day is removed (StripTime is set in the source or target), so all inputs that are not the start of a German day may be lost.
+	// The start of a day is German 6am if LossGermanMidnight is reported, too, and German midnight otherwise.
+	LossTimeOfDay InformationLoss = 1 << iota
+	// LossGermanMidnight means that German 6am is converted to German midnight but German midnight is kept, so German midnight inputs are indistinguishable from German 6am inputs afterwards.
+	// For end dates of a kind that depends on the time of day, the class contains the inputs that correspond to German midnight (e.g. 23:59:59 for enddatetimekind.INCLUSIVE_LAST_SECOND); the same applies to LossGerman6Am.
+	LossGermanMidnight
+	// LossGerman6Am means that German midnight is converted to German 6am but German 6am is kept, so German 6am inputs are indistinguishable from German midnight inputs afterwards
+	LossGerman6Am
+	// LossDSTTransition means that German days are added or subtracted (conversion from or to enddatetimekind.INCLUSIVE end dates), so inputs whose local time of day does not exist or is ambiguous on the neighbouring day (the hour of a DST transition) may be lost
+	LossDSTTransition
+)
+
+// String returns the names of the classes, separated by "|", e.g. "LossTimeOfDay|LossGermanMidnight"
+func (loss InformationLoss) String() string {
+	if loss == 0 {
+		return "Lossless"
+	}
+	names := []string{"LossTimeOfDay", "LossGermanMidnight", "LossGerman6Am", "LossDSTTransition"}
+	var result []string
+	for index, name := range names {
+		if loss&(1<<index) != 0 {
+			result = append(result, name)
+		}
+	}
+	return strings.Join(result, "|")
+}
+
+// Losses returns the classes of inputs which are possibly not reproduced by a round trip (see RoundTrip). All inputs outside of these classes are reproduced.
+func (p ConversionPlan) Losses() InformationLoss {
+	var result InformationLoss
+	if p.stripSource || p.stripTarget {
+		result |= LossTimeOfDay
+	}
+	if p.identity {
+		return result
+	}
+	switch p.gasTagShift {
+	case gasTagToMidnight:
+		if !p.stripSource { // otherwise the German 6am produced by the inverse conversion is stripped back to German midnight
+			result |= LossGermanMidnight
+		}
+	case midnightToGasTag:
+		result |= LossGerman6Am
+	}
+	if p.toExclusive.days != 0 || p.fromExclusive.days != 0 {
+		result |= LossDSTTransition
+	}
+	return result
+}
+
+// IsLossless returns true if every input is reproduced by a round trip
+func (p ConversionPlan) IsLossless() bool {
+	return p.Losses() == 0
+}
+
+// Losses validates the configuration and returns the classes of inputs which are possibly not reproduced by a round trip (see ConversionPlan.Losses)
+func Losses(configuration DateTimeConversionConfiguration) (InformationLoss, error) {
+	plan, err := compile(configuration)
+	if err != nil {
+		return 0, err
+	}
+	return plan.Losses(), nil
+}
+
+// IsLossless validates the configuration and returns true if converting with the configuration and converting back with the inverted configuration reproduces every input
+func IsLossless(configuration DateTimeConversionConfiguration) (bool, error) {
+	losses, err := Losses(configuration)
+	if err != nil {
+		return false, err
+	}
+	return losses == 0, nil
+}
+
+// RoundTripResult is the result of RoundTrip
+type RoundTripResult struct {
+	// Input is the original timestamp
+	Input time.Time `json:"input"`
+	// Converted is the Input converted with the configuration
+	Converted time.Time `json:"converted"`
+	// Reverted is Converted converted back with the inverted configuration
+	Reverted time.Time `json:"reverted"`
+	// Lossless is true iff Reverted is the same instant as Input
+	Lossless bool `json:"lossless"`
+}
+
+// RoundTrip uses the converter to convert the timestamp with the configuration and to convert the result back with the inverted configuration. The RoundTripResult tells whether the input has been reproduced.
+func RoundTrip(converter GasTagConverter, timestamp time.Time, configuration DateTimeConversionConfiguration) (RoundTripResult, error) {
+	plan, err := converter.Compile(configuration)
+	if err != nil {
+		return RoundTripResult{}, err
+	}
+	inversePlan, err := converter.Compile(configuration.Invert())
+	if err != nil {
+		return RoundTripResult{}, err
+	}
+	converted, err := plan.Apply(timestamp)
+	if err != nil {
+		return RoundTripResult{}, err
+	}
+	reverted, err := inversePlan.Apply(converted)
+	if err != nil {
+		return RoundTripResult{}, err
+	}
+	return RoundTripResult{Input: timestamp, Converted: converted, Reverted: reverted, Lossless: reverted.Equal(timestamp)}, nil
+}
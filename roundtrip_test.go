@@ -0,0 +1,100 @@
+package mako_time_converter_test
+
+import (
+	"errors"
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/mako_time_converter"
+	"time"
+)
+
+// germanLocalTimes returns the given local time of day in Berlin on every 5th day of 2023 and on the days around the DST transitions
+func germanLocalTimes(hour, minute int) []time.Time {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	var result []time.Time
+	for day := 1; day <= 365; day += 5 {
+		result = append(result, time.Date(2023, 1, day, hour, minute, 0, 0, berlin))
+	}
+	for _, day := range []int{25, 26, 27} {
+		result = append(result, time.Date(2023, 3, day, hour, minute, 0, 0, berlin), time.Date(2023, 10, day+3, hour, minute, 0, 0, berlin))
+	}
+	return result
+}
+
+func (s *Suite) Test_Losses() {
+	then.AssertThat(s.T(), len(allConversionConfigurations()), is.EqualTo(500))
+	losses, err := mako_time_converter.Losses(mako_time_converter.DateTimeConversionConfiguration{Source: mako_time_converter.MaKoStandardGas(), Target: mako_time_converter.LegacyMidnightGas()})
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), losses, is.EqualTo(mako_time_converter.LossGermanMidnight))
+	losses, err = mako_time_converter.Losses(mako_time_converter.DateTimeConversionConfiguration{Source: mako_time_converter.MaKoStandardGas(), Target: mako_time_converter.InclusiveDateOnlyGas()})
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), losses.String(), is.EqualTo("LossTimeOfDay|LossGermanMidnight|LossDSTTransition"))
+	isLossless, err := mako_time_converter.IsLossless(mako_time_converter.DateTimeConversionConfiguration{Source: mako_time_converter.MaKoStandardStrom(), Target: mako_time_converter.MaKoStandardStrom()})
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), isLossless, is.True())
+	then.AssertThat(s.T(), mako_time_converter.InformationLoss(0).String(), is.EqualTo("Lossless"))
+
+	_, err = mako_time_converter.IsLossless(mako_time_converter.DateTimeConversionConfiguration{Source: mako_time_converter.MaKoStandardGas(), Target: mako_time_converter.MaKoStandardStrom()})
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+	_, err = mako_time_converter.RoundTrip(getBerlinConverter(), time.Now(), mako_time_converter.DateTimeConversionConfiguration{Source: mako_time_converter.MaKoStandardGas(), Target: mako_time_converter.MaKoStandardStrom()})
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+}
+
+func (s *Suite) Test_RoundTrip() {
+	result, err := mako_time_converter.RoundTrip(getBerlinConverter(), time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC), mako_time_converter.DateTimeConversionConfiguration{Source: mako_time_converter.MaKoStandardGas(), Target: mako_time_converter.LegacyMidnightGas()})
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), result, is.EqualTo(mako_time_converter.RoundTripResult{
+		Input:     time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC),
+		Converted: time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC),
+		Reverted:  time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC), // German midnight is lost
+		Lossless:  false,
+	}))
+}
+
+// Test_RoundTrip_Properties checks for every valid configuration that (a) all inputs outside of the reported loss classes survive a round trip and (b) every reported loss class contains at least one input that does not survive a round trip
+func (s *Suite) Test_RoundTrip_Properties() {
+	converter := getBerlinConverter()
+	midnights := germanLocalTimes(0, 0)
+	sixAms := germanLocalTimes(6, 0)
+	otherTimes := append(germanLocalTimes(12, 34), germanLocalTimes(23, 59)...)
+	dstTransitionHours := germanLocalTimes(2, 30)
+	roundTripsLossless := func(configuration mako_time_converter.DateTimeConversionConfiguration, timestamps []time.Time) bool {
+		for _, timestamp := range timestamps {
+			result, err := mako_time_converter.RoundTrip(converter, timestamp, configuration)
+			then.AssertThat(s.T(), err, is.Nil())
+			if !result.Lossless {
+				return false
+			}
+		}
+		return true
+	}
+	for _, configuration := range allConversionConfigurations() {
+		losses, err := mako_time_converter.Losses(configuration)
+		then.AssertThat(s.T(), err, is.Nil())
+		isLost := func(classes ...mako_time_converter.InformationLoss) bool {
+			for _, class := range classes {
+				if losses&class != 0 {
+					return true
+				}
+			}
+			return false
+		}
+		// the classes refer to the exclusive end dates, so the inputs are shifted for end date kinds like enddatetimekind.INCLUSIVE_LAST_SECOND
+		offset := sourceOffset(configuration)
+		shift := func(timestamps []time.Time) []time.Time {
+			result := make([]time.Time, 0, len(timestamps))
+			for _, timestamp := range timestamps {
+				result = append(result, timestamp.Add(-offset))
+			}
+			return result
+		}
+		midnights, sixAms, otherTimes, dstTransitionHours := shift(midnights), shift(sixAms), shift(otherTimes), shift(dstTransitionHours)
+		// each class of inputs survives the round trip iff none of the loss classes it belongs to is reported
+		then.AssertThat(s.T(), roundTripsLossless(configuration, midnights), is.EqualTo(!isLost(mako_time_converter.LossGermanMidnight)))
+		// German 6am is the start of the day iff German midnight is lost, so it only loses its time of day otherwise
+		sixAmLosesTimeOfDay := isLost(mako_time_converter.LossTimeOfDay) && !isLost(mako_time_converter.LossGermanMidnight)
+		then.AssertThat(s.T(), roundTripsLossless(configuration, sixAms), is.EqualTo(!isLost(mako_time_converter.LossGerman6Am) && !sixAmLosesTimeOfDay))
+		then.AssertThat(s.T(), roundTripsLossless(configuration, otherTimes), is.EqualTo(!isLost(mako_time_converter.LossTimeOfDay)))
+		then.AssertThat(s.T(), roundTripsLossless(configuration, dstTransitionHours), is.EqualTo(!isLost(mako_time_converter.LossDSTTransition, mako_time_converter.LossTimeOfDay)))
+	}
+}
@@ -0,0 +1,264 @@
+// Package server exposes a GasTagConverter as JSON HTTP endpoints, so that services which are not written in Go share the same conversion logic.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hochfrequenz/mako_time_converter"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ConvertRequest is the body of POST /convert; all fields are required
+type ConvertRequest struct {
+	Timestamp     *time.Time                                           `json:"timestamp"`
+	Configuration *mako_time_converter.DateTimeConversionConfiguration `json:"configuration"`
+}
+
+func (body ConvertRequest) missingField() string {
+	switch {
+	case body.Timestamp == nil:
+		return "timestamp"
+	case body.Configuration == nil:
+		return "configuration"
+	}
+	return ""
+}
+
+// ConvertResponse is the body of a successful response of POST /convert
+type ConvertResponse struct {
+	Result time.Time `json:"result"`
+}
+
+// BatchConvertRequest is the body of POST /convert/batch; all fields are required (Timestamps may be empty)
+type BatchConvertRequest struct {
+	Timestamps    []time.Time                                          `json:"timestamps"`
+	Configuration *mako_time_converter.DateTimeConversionConfiguration `json:"configuration"`
+}
+
+func (body BatchConvertRequest) missingField() string {
+	switch {
+	case body.Timestamps == nil:
+		return "timestamps"
+	case body.Configuration == nil:
+		return "configuration"
+	}
+	return ""
+}
+
+// BatchConvertResult is the result of converting a single timestamp of a BatchConvertRequest
+type BatchConvertResult struct {
+	Input time.Time `json:"input"`
+	// Output is nil if Error is not nil
+	Output *time.Time `json:"output,omitempty"`
+	// Error is set if the Input could not be converted
+	Error *ErrorDetail `json:"error,omitempty"`
+}
+
+// BatchConvertResponse is the body of a successful response of POST /convert/batch. There is one result per timestamp of the request (in the same order).
+type BatchConvertResponse struct {
+	Results []BatchConvertResult `json:"results"`
+}
+
+// IntervalRequest is the body of POST /interval; all fields are required
+type IntervalRequest struct {
+	Interval      *mako_time_converter.Interval                        `json:"interval"`
+	Configuration *mako_time_converter.DateTimeConversionConfiguration `json:"configuration"`
+}
+
+func (body IntervalRequest) missingField() string {
+	switch {
+	case body.Interval == nil:
+		return "interval"
+	case body.Configuration == nil:
+		return "configuration"
+	}
+	return ""
+}
+
+// requestBody is implemented by the bodies of the POST requests
+type requestBody interface {
+	// missingField returns the name of a required field that is missing in the request body or "" if all required fields are set
+	missingField() string
+}
+
+// IntervalResponse is the body of a successful response of POST /interval and GET /gasday
+type IntervalResponse struct {
+	Result mako_time_converter.Interval `json:"result"`
+}
+
+// ErrorCode is a machine-readable description of what went wrong
+type ErrorCode string
+
+const (
+	// InvalidRequest means that the request could not be parsed
+	InvalidRequest ErrorCode = "INVALID_REQUEST"
+	// InvalidConfiguration means that the DateTimeConversionConfiguration is invalid (see mako_time_converter.InvalidConfigurationError)
+	InvalidConfiguration ErrorCode = "INVALID_CONFIGURATION"
+	// InvalidInterval means that the end of an interval is before its start (see mako_time_converter.InvalidIntervalError)
+	InvalidInterval ErrorCode = "INVALID_INTERVAL"
+	// ConversionFailed means that the conversion failed for any other reason
+	ConversionFailed ErrorCode = "CONVERSION_FAILED"
+	// RequestTooLarge means that the request body is larger than MaxRequestBytes or a batch contains more than MaxBatchSize timestamps
+	RequestTooLarge ErrorCode = "REQUEST_TOO_LARGE"
+)
+
+const (
+	// MaxRequestBytes is the maximum size of a request body in bytes
+	MaxRequestBytes = 1 << 20
+	// MaxBatchSize is the maximum number of timestamps in a BatchConvertRequest
+	MaxBatchSize = 10000
+)
+
+// ErrorDetail describes an error
+type ErrorDetail struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+	// Field is the invalid field of the configuration (only for InvalidConfiguration)
+	Field string `json:"field,omitempty"`
+	// Rule is the violated rule (only for InvalidConfiguration)
+	Rule string `json:"rule,omitempty"`
+}
+
+// ErrorResponse is the body of all responses with a status code other than 200
+type ErrorResponse struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// newErrorDetail maps the errors of the mako_time_converter package to an ErrorDetail
+func newErrorDetail(err error) ErrorDetail {
+	var configurationError mako_time_converter.InvalidConfigurationError
+	switch {
+	case errors.As(err, &configurationError):
+		return ErrorDetail{Code: InvalidConfiguration, Message: err.Error(), Field: configurationError.Field, Rule: configurationError.Rule}
+	case errors.Is(err, mako_time_converter.ErrInvalidInterval):
+		return ErrorDetail{Code: InvalidInterval, Message: err.Error()}
+	default:
+		return ErrorDetail{Code: ConversionFailed, Message: err.Error()}
+	}
+}
+
+type handler struct {
+	converter  mako_time_converter.GasTagConverter
+	boundaries mako_time_converter.Boundaries
+}
+
+// NewHandler returns an http.Handler that provides the following endpoints:
+//   - POST /convert (ConvertRequest → ConvertResponse)
+//   - POST /convert/batch (BatchConvertRequest → BatchConvertResponse)
+//   - POST /interval (IntervalRequest → IntervalResponse)
+//   - GET /gasday?t=2023-01-01T05:00:00Z (→ IntervalResponse with the German Gastag to which t belongs)
+//
+// Errors are returned as ErrorResponse with status code 400 (invalid request, e.g. a missing required field), 413 (the body is larger than MaxRequestBytes or the batch is larger than MaxBatchSize) or 422 (the request is well-formed but cannot be converted).
+func NewHandler(converter mako_time_converter.GasTagConverter) http.Handler {
+	h := handler{converter: converter, boundaries: mako_time_converter.NewBoundaries(converter)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /convert", h.convert)
+	mux.HandleFunc("POST /convert/batch", h.convertBatch)
+	mux.HandleFunc("POST /interval", h.interval)
+	mux.HandleFunc("GET /gasday", h.gasDay)
+	return mux
+}
+
+func writeJson(writer http.ResponseWriter, statusCode int, body any) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(statusCode)
+	_ = json.NewEncoder(writer).Encode(body)
+}
+
+func writeError(writer http.ResponseWriter, statusCode int, detail ErrorDetail) {
+	writeJson(writer, statusCode, ErrorResponse{Error: detail})
+}
+
+// readRequest decodes the JSON body of the request into target and writes an error response if this is not possible, the body contains more than one JSON value or a required field is missing
+func readRequest(writer http.ResponseWriter, request *http.Request, target requestBody) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(writer, request.Body, MaxRequestBytes))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(target)
+	if err == nil {
+		if trailingErr := decoder.Decode(&json.RawMessage{}); trailingErr == nil {
+			err = errors.New("the request body must contain a single JSON object")
+		} else if !errors.Is(trailingErr, io.EOF) {
+			err = fmt.Errorf("invalid data after the JSON object: %w", trailingErr)
+		}
+	}
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			writeError(writer, http.StatusRequestEntityTooLarge, ErrorDetail{Code: RequestTooLarge, Message: fmt.Sprintf("the request body must not be larger than %d bytes", maxBytesError.Limit)})
+			return false
+		}
+		writeError(writer, http.StatusBadRequest, ErrorDetail{Code: InvalidRequest, Message: err.Error()})
+		return false
+	}
+	if field := target.missingField(); field != "" {
+		writeError(writer, http.StatusBadRequest, ErrorDetail{Code: InvalidRequest, Message: fmt.Sprintf("the field '%s' is required", field)})
+		return false
+	}
+	return true
+}
+
+func (h handler) convert(writer http.ResponseWriter, request *http.Request) {
+	var body ConvertRequest
+	if !readRequest(writer, request, &body) {
+		return
+	}
+	result, err := h.converter.Convert(*body.Timestamp, *body.Configuration)
+	if err != nil {
+		writeError(writer, http.StatusUnprocessableEntity, newErrorDetail(err))
+		return
+	}
+	writeJson(writer, http.StatusOK, ConvertResponse{Result: result})
+}
+
+func (h handler) convertBatch(writer http.ResponseWriter, request *http.Request) {
+	var body BatchConvertRequest
+	if !readRequest(writer, request, &body) {
+		return
+	}
+	if len(body.Timestamps) > MaxBatchSize {
+		writeError(writer, http.StatusRequestEntityTooLarge, ErrorDetail{Code: RequestTooLarge, Message: fmt.Sprintf("the batch must not contain more than %d timestamps but contains %d", MaxBatchSize, len(body.Timestamps))})
+		return
+	}
+	conversionResults, err := h.converter.ConvertAll(body.Timestamps, *body.Configuration)
+	if err != nil {
+		writeError(writer, http.StatusUnprocessableEntity, newErrorDetail(err))
+		return
+	}
+	response := BatchConvertResponse{Results: make([]BatchConvertResult, len(conversionResults))}
+	for index, conversionResult := range conversionResults {
+		response.Results[index].Input = conversionResult.Input
+		if conversionResult.Err != nil {
+			detail := newErrorDetail(conversionResult.Err)
+			response.Results[index].Error = &detail
+			continue
+		}
+		output := conversionResult.Output
+		response.Results[index].Output = &output
+	}
+	writeJson(writer, http.StatusOK, response)
+}
+
+func (h handler) interval(writer http.ResponseWriter, request *http.Request) {
+	var body IntervalRequest
+	if !readRequest(writer, request, &body) {
+		return
+	}
+	result, err := h.converter.ConvertInterval(*body.Interval, *body.Configuration)
+	if err != nil {
+		writeError(writer, http.StatusUnprocessableEntity, newErrorDetail(err))
+		return
+	}
+	writeJson(writer, http.StatusOK, IntervalResponse{Result: result})
+}
+
+func (h handler) gasDay(writer http.ResponseWriter, request *http.Request) {
+	timestamp, err := time.Parse(time.RFC3339Nano, request.URL.Query().Get("t"))
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, ErrorDetail{Code: InvalidRequest, Message: "the query parameter t must be an RFC 3339 date time: " + err.Error()})
+		return
+	}
+	writeJson(writer, http.StatusOK, IntervalResponse{Result: h.boundaries.GasDay(timestamp)})
+}
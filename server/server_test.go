@@ -0,0 +1,151 @@
+package server_test
+
+import (
+	"encoding/json"
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/mako_time_converter"
+	"github.com/hochfrequenz/mako_time_converter/server"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type Suite struct {
+	suite.Suite
+	handler http.Handler
+}
+
+// SetupSuite sets up the tests
+func (s *Suite) SetupSuite() {
+	s.handler = server.NewHandler(mako_time_converter.NewGasTagConverter("Europe/Berlin"))
+}
+
+func TestInit(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+const inclusiveToExclusiveGasConfiguration = `{"source":{"isGas":true,"isGasTagAware":false,"isEndDate":true,"endDateTimeKind":"INCLUSIVE"},"target":{"isGas":true,"isGasTagAware":true,"isEndDate":true,"endDateTimeKind":"EXCLUSIVE"}}`
+
+const invalidConfiguration = `{"source":{"isGas":true,"isGasTagAware":false},"target":{"isGas":true}}`
+
+// serve sends the request to the handler and decodes the response body into response
+func (s *Suite) serve(method, path, body string, response any) int {
+	recorder := httptest.NewRecorder()
+	s.handler.ServeHTTP(recorder, httptest.NewRequest(method, path, strings.NewReader(body)))
+	if response != nil {
+		then.AssertThat(s.T(), recorder.Header().Get("Content-Type"), is.EqualTo("application/json"))
+		then.AssertThat(s.T(), json.Unmarshal(recorder.Body.Bytes(), response), is.Nil())
+	}
+	return recorder.Code
+}
+
+func (s *Suite) Test_Convert() {
+	var response server.ConvertResponse
+	statusCode := s.serve(http.MethodPost, "/convert", `{"timestamp":"2022-12-31T23:00:00Z","configuration":`+inclusiveToExclusiveGasConfiguration+`}`, &response)
+	then.AssertThat(s.T(), statusCode, is.EqualTo(http.StatusOK))
+	then.AssertThat(s.T(), response.Result.Equal(time.Date(2023, 1, 2, 5, 0, 0, 0, time.UTC)), is.True())
+}
+
+func (s *Suite) Test_Convert_Errors() {
+	var response server.ErrorResponse
+	statusCode := s.serve(http.MethodPost, "/convert", `{"timestamp":"2022-12-31T23:00:00Z","configuration":`+invalidConfiguration+`}`, &response)
+	then.AssertThat(s.T(), statusCode, is.EqualTo(http.StatusUnprocessableEntity))
+	then.AssertThat(s.T(), response.Error.Code, is.EqualTo(server.InvalidConfiguration))
+	then.AssertThat(s.T(), response.Error.Field, is.EqualTo("Target.IsGasTagAware"))
+	then.AssertThat(s.T(), response.Error.Rule, is.EqualTo("required_if"))
+
+	for _, invalidBody := range []string{`{"timestamp":"yesterday"}`, `{"unknown":1}`, `[`} {
+		response = server.ErrorResponse{}
+		statusCode = s.serve(http.MethodPost, "/convert", invalidBody, &response)
+		then.AssertThat(s.T(), statusCode, is.EqualTo(http.StatusBadRequest))
+		then.AssertThat(s.T(), response.Error.Code, is.EqualTo(server.InvalidRequest))
+	}
+
+	statusCode = s.serve(http.MethodGet, "/convert", "", nil)
+	then.AssertThat(s.T(), statusCode, is.EqualTo(http.StatusMethodNotAllowed))
+}
+
+func (s *Suite) Test_Convert_Batch() {
+	var response server.BatchConvertResponse
+	statusCode := s.serve(http.MethodPost, "/convert/batch", `{"timestamps":["2022-12-31T23:00:00Z","2023-05-31T22:00:00Z"],"configuration":`+inclusiveToExclusiveGasConfiguration+`}`, &response)
+	then.AssertThat(s.T(), statusCode, is.EqualTo(http.StatusOK))
+	then.AssertThat(s.T(), len(response.Results), is.EqualTo(2))
+	then.AssertThat(s.T(), response.Results[0].Error == nil, is.True())
+	then.AssertThat(s.T(), response.Results[0].Output.Equal(time.Date(2023, 1, 2, 5, 0, 0, 0, time.UTC)), is.True())
+	then.AssertThat(s.T(), response.Results[1].Input.Equal(time.Date(2023, 5, 31, 22, 0, 0, 0, time.UTC)), is.True())
+	then.AssertThat(s.T(), response.Results[1].Output.Equal(time.Date(2023, 6, 2, 4, 0, 0, 0, time.UTC)), is.True())
+
+	var errorResponse server.ErrorResponse
+	statusCode = s.serve(http.MethodPost, "/convert/batch", `{"timestamps":["2022-12-31T23:00:00Z"],"configuration":`+invalidConfiguration+`}`, &errorResponse)
+	then.AssertThat(s.T(), statusCode, is.EqualTo(http.StatusUnprocessableEntity))
+	then.AssertThat(s.T(), errorResponse.Error.Code, is.EqualTo(server.InvalidConfiguration))
+	statusCode = s.serve(http.MethodPost, "/convert/batch", `{"timestamps":"2022-12-31T23:00:00Z"}`, &errorResponse)
+	then.AssertThat(s.T(), statusCode, is.EqualTo(http.StatusBadRequest))
+}
+
+func (s *Suite) Test_Request_Limits() {
+	var errorResponse server.ErrorResponse
+	timestamps := strings.Repeat(`"2022-12-31T23:00:00Z",`, server.MaxBatchSize) + `"2022-12-31T23:00:00Z"`
+	statusCode := s.serve(http.MethodPost, "/convert/batch", `{"timestamps":[`+timestamps+`],"configuration":`+inclusiveToExclusiveGasConfiguration+`}`, &errorResponse)
+	then.AssertThat(s.T(), statusCode, is.EqualTo(http.StatusRequestEntityTooLarge))
+	then.AssertThat(s.T(), errorResponse.Error.Code, is.EqualTo(server.RequestTooLarge))
+
+	errorResponse = server.ErrorResponse{}
+	statusCode = s.serve(http.MethodPost, "/convert", `{"timestamp":"`+strings.Repeat("x", server.MaxRequestBytes)+`"}`, &errorResponse)
+	then.AssertThat(s.T(), statusCode, is.EqualTo(http.StatusRequestEntityTooLarge))
+	then.AssertThat(s.T(), errorResponse.Error.Code, is.EqualTo(server.RequestTooLarge))
+}
+
+func (s *Suite) Test_Incomplete_Requests() {
+	for _, invalidRequest := range []struct{ path, body string }{
+		{path: "/convert", body: `{}`},
+		{path: "/convert", body: `{"timestamp":"2022-12-31T23:00:00Z"}`},
+		{path: "/convert/batch", body: `{"configuration":` + inclusiveToExclusiveGasConfiguration + `}`},
+		{path: "/interval", body: `{}`},
+		{path: "/interval", body: `{"interval":{"start":"2022-12-31T23:00:00Z","end":"2023-01-30T23:00:00Z"}}`},
+		{path: "/convert", body: `{"timestamp":"2022-12-31T23:00:00Z","configuration":` + inclusiveToExclusiveGasConfiguration + `} garbage`},
+		{path: "/convert/batch", body: `{"timestamps":[],"configuration":` + inclusiveToExclusiveGasConfiguration + `}{}`},
+	} {
+		var errorResponse server.ErrorResponse
+		statusCode := s.serve(http.MethodPost, invalidRequest.path, invalidRequest.body, &errorResponse)
+		then.AssertThat(s.T(), statusCode, is.EqualTo(http.StatusBadRequest))
+		then.AssertThat(s.T(), errorResponse.Error.Code, is.EqualTo(server.InvalidRequest))
+	}
+
+	var response server.BatchConvertResponse
+	statusCode := s.serve(http.MethodPost, "/convert/batch", `{"timestamps":[],"configuration":`+inclusiveToExclusiveGasConfiguration+"}\n", &response)
+	then.AssertThat(s.T(), statusCode, is.EqualTo(http.StatusOK))
+	then.AssertThat(s.T(), len(response.Results), is.EqualTo(0))
+}
+
+func (s *Suite) Test_Interval() {
+	var response server.IntervalResponse
+	statusCode := s.serve(http.MethodPost, "/interval", `{"interval":{"start":"2022-12-31T23:00:00Z","end":"2023-01-30T23:00:00Z"},"configuration":`+inclusiveToExclusiveGasConfiguration+`}`, &response)
+	then.AssertThat(s.T(), statusCode, is.EqualTo(http.StatusOK))
+	then.AssertThat(s.T(), response.Result.Start.Equal(time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC)), is.True())
+	then.AssertThat(s.T(), response.Result.End.Equal(time.Date(2023, 2, 1, 5, 0, 0, 0, time.UTC)), is.True())
+
+	var errorResponse server.ErrorResponse
+	statusCode = s.serve(http.MethodPost, "/interval", `{"interval":{"start":"2023-01-30T23:00:00Z","end":"2022-12-31T23:00:00Z"},"configuration":`+inclusiveToExclusiveGasConfiguration+`}`, &errorResponse)
+	then.AssertThat(s.T(), statusCode, is.EqualTo(http.StatusUnprocessableEntity))
+	then.AssertThat(s.T(), errorResponse.Error.Code, is.EqualTo(server.InvalidInterval))
+	statusCode = s.serve(http.MethodPost, "/interval", `{"interval":1}`, &errorResponse)
+	then.AssertThat(s.T(), statusCode, is.EqualTo(http.StatusBadRequest))
+}
+
+func (s *Suite) Test_GasDay() {
+	var response server.IntervalResponse
+	statusCode := s.serve(http.MethodGet, "/gasday?t=2023-01-01T02:00:00Z", "", &response)
+	then.AssertThat(s.T(), statusCode, is.EqualTo(http.StatusOK))
+	then.AssertThat(s.T(), response.Result.Start.Equal(time.Date(2022, 12, 31, 5, 0, 0, 0, time.UTC)), is.True())
+	then.AssertThat(s.T(), response.Result.End.Equal(time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC)), is.True())
+
+	var errorResponse server.ErrorResponse
+	statusCode = s.serve(http.MethodGet, "/gasday?t=today", "", &errorResponse)
+	then.AssertThat(s.T(), statusCode, is.EqualTo(http.StatusBadRequest))
+	then.AssertThat(s.T(), errorResponse.Error.Code, is.EqualTo(server.InvalidRequest))
+}
@@ -0,0 +1,60 @@
+package mako_time_converter
+
+import (
+	"fmt"
+	"time"
+)
+
+// QuarterHour is the resolution of (RLM/iMS) Lastgang time series
+const QuarterHour = 15 * time.Minute
+
+// SlotCount returns the number of slots of the given resolution in the interval, e.g. 92, 96 or 100 quarter hours for a German day (depending on DST). It returns an error if the interval cannot be divided into slots of the given resolution.
+func SlotCount(interval Interval, resolution time.Duration) (int, error) {
+	if err := interval.Validate(); err != nil {
+		return 0, err
+	}
+	if resolution <= 0 {
+		return 0, fmt.Errorf("%w: the resolution must be positive but was %v", ErrInvalidResolution, resolution)
+	}
+	duration := interval.End.Sub(interval.Start)
+	if duration%resolution != 0 {
+		return 0, fmt.Errorf("%w: the interval %v-%v of %v cannot be divided into slots of %v", ErrInvalidResolution, interval.Start, interval.End, duration, resolution)
+	}
+	return int(duration / resolution), nil
+}
+
+// Slots returns the (UTC) starts of all slots of the given resolution in the interval (with exclusive end)
+func Slots(interval Interval, resolution time.Duration) ([]time.Time, error) {
+	count, err := SlotCount(interval, resolution)
+	if err != nil {
+		return nil, err
+	}
+	slots := make([]time.Time, count)
+	start := interval.Start.UTC()
+	for index := range slots {
+		// the slots are calculated in UTC; there's no ambiguity because German local time and UTC differ by full hours only
+		slots[index] = start.Add(time.Duration(index) * resolution)
+	}
+	return slots, nil
+}
+
+// QuarterHours returns the (UTC) starts of all quarter hours in the interval (with exclusive end), e.g. QuarterHours(boundaries.GasDay(t))
+func QuarterHours(interval Interval) ([]time.Time, error) {
+	return Slots(interval, QuarterHour)
+}
+
+// Hours returns the (UTC) starts of all hours in the interval (with exclusive end), e.g. Hours(boundaries.StromDay(t))
+func Hours(interval Interval) ([]time.Time, error) {
+	return Slots(interval, time.Hour)
+}
+
+// SlotIndex returns the zero based index of the slot of the given resolution in the interval to which the timestamp belongs. It returns an error if the timestamp is not within the interval.
+func SlotIndex(interval Interval, timestamp time.Time, resolution time.Duration) (int, error) {
+	if _, err := SlotCount(interval, resolution); err != nil {
+		return 0, err
+	}
+	if timestamp.Before(interval.Start) || !timestamp.Before(interval.End) {
+		return 0, fmt.Errorf("%w: %v is not within %v-%v", ErrOutsideInterval, timestamp, interval.Start, interval.End)
+	}
+	return int(timestamp.Sub(interval.Start) / resolution), nil
+}
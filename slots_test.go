@@ -0,0 +1,89 @@
+package mako_time_converter_test
+
+import (
+	"errors"
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/mako_time_converter"
+	"time"
+)
+
+func (s *Suite) Test_QuarterHours_Of_Strom_Days() {
+	expectedCounts := map[time.Time]int{
+		time.Date(2023, 3, 26, 12, 0, 0, 0, time.UTC):  92,
+		time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC):  96,
+		time.Date(2023, 10, 29, 12, 0, 0, 0, time.UTC): 100,
+	}
+	converter := getBerlinConverter()
+	boundaries := mako_time_converter.NewBoundaries(converter)
+	for timestamp, expectedCount := range expectedCounts {
+		day := boundaries.StromDay(timestamp)
+		quarterHours, err := mako_time_converter.QuarterHours(day)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), len(quarterHours), is.EqualTo(expectedCount))
+		then.AssertThat(s.T(), quarterHours[0], is.EqualTo(day.Start))
+		then.AssertThat(s.T(), quarterHours[len(quarterHours)-1], is.EqualTo(day.End.Add(-mako_time_converter.QuarterHour)))
+		count, err := mako_time_converter.SlotCount(day, mako_time_converter.QuarterHour)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), count, is.EqualTo(expectedCount))
+		hours, err := mako_time_converter.Hours(day)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), len(hours), is.EqualTo(expectedCount/4))
+	}
+}
+
+func (s *Suite) Test_QuarterHours_Of_Gas_Days() {
+	expectedCounts := map[time.Time]int{
+		time.Date(2023, 3, 25, 12, 0, 0, 0, time.UTC):  92, // 2023-03-25 06:00 CET to 2023-03-26 06:00 CEST
+		time.Date(2023, 3, 26, 12, 0, 0, 0, time.UTC):  96,
+		time.Date(2023, 10, 28, 12, 0, 0, 0, time.UTC): 100, // 2023-10-28 06:00 CEST to 2023-10-29 06:00 CET
+		time.Date(2023, 10, 29, 3, 0, 0, 0, time.UTC):  100, // 04:00 CET still belongs to the gas day of 2023-10-28
+	}
+	converter := getBerlinConverter()
+	boundaries := mako_time_converter.NewBoundaries(converter)
+	for timestamp, expectedCount := range expectedCounts {
+		day := boundaries.GasDay(timestamp)
+		then.AssertThat(s.T(), converter.IsGerman6Am(day.Start), is.True())
+		then.AssertThat(s.T(), converter.IsGerman6Am(day.End), is.True())
+		quarterHours, err := mako_time_converter.QuarterHours(day)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), len(quarterHours), is.EqualTo(expectedCount))
+	}
+}
+
+func (s *Suite) Test_SlotIndex() {
+	converter := getBerlinConverter()
+	boundaries := mako_time_converter.NewBoundaries(converter)
+	day := boundaries.StromDay(time.Date(2023, 10, 29, 12, 0, 0, 0, time.UTC)) // 25h day
+	quarterHours, err := mako_time_converter.QuarterHours(day)
+	then.AssertThat(s.T(), err, is.Nil())
+	for expectedIndex, quarterHour := range quarterHours {
+		index, err := mako_time_converter.SlotIndex(day, quarterHour, mako_time_converter.QuarterHour)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), index, is.EqualTo(expectedIndex))
+		index, err = mako_time_converter.SlotIndex(day, quarterHour.Add(14*time.Minute), mako_time_converter.QuarterHour)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), index, is.EqualTo(expectedIndex))
+	}
+	// 02:30 CET (the second 02:30 of the day) is in the 3rd hour after the first 02:00 CEST
+	index, err := mako_time_converter.SlotIndex(day, time.Date(2023, 10, 29, 1, 30, 0, 0, time.UTC), time.Hour)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), index, is.EqualTo(3))
+
+	_, err = mako_time_converter.SlotIndex(day, day.End, mako_time_converter.QuarterHour)
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrOutsideInterval), is.True())
+	_, err = mako_time_converter.SlotIndex(day, day.Start.Add(-time.Nanosecond), mako_time_converter.QuarterHour)
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrOutsideInterval), is.True())
+}
+
+func (s *Suite) Test_Slots_Invalid_Resolution() {
+	converter := getBerlinConverter()
+	boundaries := mako_time_converter.NewBoundaries(converter)
+	day := boundaries.StromDay(time.Date(2023, 10, 29, 12, 0, 0, 0, time.UTC))
+	_, err := mako_time_converter.Slots(day, 0)
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidResolution), is.True())
+	_, err = mako_time_converter.Slots(day, 7*time.Hour) // 25h cannot be divided into 7h slots
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidResolution), is.True())
+	_, err = mako_time_converter.Slots(mako_time_converter.Interval{Start: day.End, End: day.Start}, time.Hour)
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidInterval), is.True())
+}
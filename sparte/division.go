@@ -0,0 +1,45 @@
+package sparte
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownDivisionCode is returned if a division code cannot be mapped to a Sparte
+var ErrUnknownDivisionCode = errors.New("unknown division code")
+
+// germanNames maps the German (BO4E) names of the Sparten to the Sparten; the names are upper case and may contain umlauts
+var germanNames = map[string]Sparte{
+	"STROM":        STROM,
+	"ELEKTRIZITÄT": STROM,
+	"GAS":          GAS,
+	"FERNWÄRME":    FERNWAERME,
+	"NAHWÄRME":     NAHWAERME,
+	"WASSER":       WASSER,
+	"ABWASSER":     ABWASSER,
+}
+
+// ParseDivisionCode returns the Sparte of a division code as it is used in EDIFACT messages of the German Marktkommunikation. It understands
+//   - the association assigned code of the EDI@Energy message identifier (UNH segment), which starts with "S" for Strom and "G" for Gas, e.g. "S2.1" or "G1.0a"
+//   - the names of the Sparten (case-insensitive, with or without umlauts), e.g. "STROM", "Gas", "Fernwärme" or "FERNWAERME"
+//
+// It returns an error that wraps ErrUnknownDivisionCode for all other codes.
+func ParseDivisionCode(code string) (Sparte, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(code))
+	if result, found := _SparteNameToValue[normalized]; found {
+		return result, nil
+	}
+	if result, found := germanNames[normalized]; found {
+		return result, nil
+	}
+	if len(normalized) > 1 && normalized[1] >= '0' && normalized[1] <= '9' {
+		switch normalized[0] {
+		case 'S':
+			return STROM, nil
+		case 'G':
+			return GAS, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrUnknownDivisionCode, code)
+}
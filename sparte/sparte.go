@@ -0,0 +1,28 @@
+// Package sparte contains the Sparten (divisions) of the German energy and utility market as they are used in BO4E
+package sparte
+
+// Sparte is a division of the market, e.g. Strom or Gas
+//
+//go:generate stringer --type Sparte
+//go:generate jsonenums --type Sparte
+type Sparte int
+
+const (
+	// STROM is electricity
+	STROM Sparte = iota + 1
+	// GAS is natural gas
+	GAS
+	// FERNWAERME is district heating
+	FERNWAERME
+	// NAHWAERME is local heating
+	NAHWAERME
+	// WASSER is water
+	WASSER
+	// ABWASSER is waste water
+	ABWASSER
+)
+
+// IsValid returns true if the Sparte is one of the defined constants
+func (s Sparte) IsValid() bool {
+	return s >= STROM && s <= ABWASSER
+}
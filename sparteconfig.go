@@ -0,0 +1,63 @@
+package mako_time_converter
+
+import (
+	"errors"
+	"fmt"
+	"github.com/hochfrequenz/mako_time_converter/sparte"
+	"time"
+)
+
+// A SparteConfiguration binds conversion rule sets to Sparten, so that the configuration can be selected by the Sparte of the data (e.g. the Sparte of a BO4E object or of an EDIFACT message, see sparte.ParseDivisionCode) instead of setting DateTimeConfiguration.IsGas by hand.
+type SparteConfiguration struct {
+	// Gas is used for sparte.GAS; IsGas must be set in Source and Target
+	Gas DateTimeConversionConfiguration `json:"gas"`
+	// Strom is used for sparte.STROM and all other Sparten without an entry in Other. Water and heating (sparte.WASSER, sparte.FERNWAERME...) have no Gas-Tag; their days are calendar days (German midnight to German midnight) like Strom days.
+	Strom DateTimeConversionConfiguration `json:"strom"`
+	// Other optionally overrides the Strom configuration for Sparten other than Strom and Gas, e.g. for a water system with inclusive end dates. IsGas must not be set.
+	// Entries for sparte.STROM or sparte.GAS are invalid (use Strom and Gas instead); For rejects them.
+	Other map[sparte.Sparte]DateTimeConversionConfiguration `json:"other,omitempty"`
+}
+
+// For returns the configuration for the given Sparte with Source.Sparte and Target.Sparte bound to it. It returns an error that wraps ErrUnknownSparte if the Sparte is unknown and an InvalidConfigurationError if the configuration is invalid or does not match the Sparte (e.g. IsGas is not set in the Gas configuration or Other contains an entry for sparte.STROM or sparte.GAS).
+func (sc SparteConfiguration) For(s sparte.Sparte) (DateTimeConversionConfiguration, error) {
+	if !s.IsValid() {
+		return DateTimeConversionConfiguration{}, fmt.Errorf("%w: %d", ErrUnknownSparte, s)
+	}
+	for _, excluded := range []sparte.Sparte{sparte.STROM, sparte.GAS} {
+		if _, found := sc.Other[excluded]; found {
+			return DateTimeConversionConfiguration{}, InvalidConfigurationError{
+				Field: "Other[" + excluded.String() + "]",
+				Rule:  otherSparteRule,
+				Err:   errors.New("the configuration of " + excluded.String() + " must not be overridden in Other"),
+			}
+		}
+	}
+	var configuration DateTimeConversionConfiguration
+	switch other, hasOther := sc.Other[s]; {
+	case s == sparte.GAS:
+		configuration = sc.Gas
+	case hasOther:
+		configuration = other
+	default:
+		configuration = sc.Strom
+	}
+	result := DateTimeConversionConfiguration{Source: configuration.Source.clone(), Target: configuration.Target.clone()}
+	result.Source.Sparte = &s
+	result.Target.Sparte = &s
+	if err := validateConfiguration(result); err != nil {
+		return DateTimeConversionConfiguration{}, err
+	}
+	return result, nil
+}
+
+// ConvertForSparte uses the converter to convert the timestamp like GasTagConverter.Convert with the configuration that the SparteConfiguration binds to the given Sparte (see SparteConfiguration.For)
+func ConvertForSparte(converter GasTagConverter, timestamp time.Time, s sparte.Sparte, configuration SparteConfiguration) (time.Time, error) {
+	sparteConfiguration, err := configuration.For(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return converter.Convert(timestamp, sparteConfiguration)
+}
+
+// otherSparteRule is the rule that is violated if SparteConfiguration.Other contains an entry for sparte.STROM or sparte.GAS
+const otherSparteRule = "excluded_keys"
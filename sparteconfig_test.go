@@ -0,0 +1,136 @@
+package mako_time_converter_test
+
+import (
+	"errors"
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/mako_time_converter"
+	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
+	"github.com/hochfrequenz/mako_time_converter/sparte"
+	"time"
+)
+
+// inclusiveToMaKo returns a SparteConfiguration that converts inclusive end dates to the MaKo standard of the respective Sparte
+func inclusiveToMaKo() mako_time_converter.SparteConfiguration {
+	return mako_time_converter.SparteConfiguration{
+		Gas: mako_time_converter.DateTimeConversionConfiguration{
+			Source: mako_time_converter.InclusiveDateOnlyGas(),
+			Target: mako_time_converter.MaKoStandardGas(),
+		},
+		Strom: mako_time_converter.DateTimeConversionConfiguration{
+			Source: mako_time_converter.DateTimeConfiguration{IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.INCLUSIVE)},
+			Target: mako_time_converter.MaKoStandardStrom(),
+		},
+	}
+}
+
+func (s *Suite) Test_ConvertForSparte() {
+	converter := getBerlinConverter()
+	endOfJanuary := time.Date(2023, 1, 30, 23, 0, 0, 0, time.UTC) // 2023-01-31 inclusive
+	actual, err := mako_time_converter.ConvertForSparte(converter, endOfJanuary, sparte.GAS, inclusiveToMaKo())
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), actual, is.EqualTo(time.Date(2023, 2, 1, 5, 0, 0, 0, time.UTC)))
+	// water and heating use calendar days like Strom
+	for _, calendarDays := range []sparte.Sparte{sparte.STROM, sparte.WASSER, sparte.ABWASSER, sparte.FERNWAERME, sparte.NAHWAERME} {
+		actual, err = mako_time_converter.ConvertForSparte(converter, endOfJanuary, calendarDays, inclusiveToMaKo())
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), actual, is.EqualTo(time.Date(2023, 1, 31, 23, 0, 0, 0, time.UTC)))
+	}
+	_, err = mako_time_converter.ConvertForSparte(converter, endOfJanuary, sparte.Sparte(0), inclusiveToMaKo())
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrUnknownSparte), is.True())
+}
+
+func (s *Suite) Test_SparteConfiguration_Other_Overrides_Strom() {
+	configuration := inclusiveToMaKo()
+	configuration.Other = map[sparte.Sparte]mako_time_converter.DateTimeConversionConfiguration{
+		sparte.WASSER: {
+			Source: mako_time_converter.DateTimeConfiguration{IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.EXCLUSIVE)},
+			Target: mako_time_converter.MaKoStandardStrom(),
+		},
+	}
+	wasser, err := configuration.For(sparte.WASSER)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), *wasser.Source.EndDateTimeKind, is.EqualTo(enddatetimekind.EXCLUSIVE))
+	then.AssertThat(s.T(), *wasser.Source.Sparte, is.EqualTo(sparte.WASSER))
+	then.AssertThat(s.T(), *wasser.Target.Sparte, is.EqualTo(sparte.WASSER))
+	strom, err := configuration.For(sparte.STROM)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), *strom.Source.EndDateTimeKind, is.EqualTo(enddatetimekind.INCLUSIVE))
+	then.AssertThat(s.T(), strom.Source.Sparte == nil, is.False())
+	then.AssertThat(s.T(), configuration.Strom.Source.Sparte == nil, is.True()) // the configuration itself is not changed
+}
+
+func (s *Suite) Test_SparteConfiguration_Rejects_Invalid_Pairings() {
+	configuration := inclusiveToMaKo()
+	configuration.Gas = configuration.Strom // not gas
+	_, err := configuration.For(sparte.GAS)
+	var invalidConfigurationError mako_time_converter.InvalidConfigurationError
+	then.AssertThat(s.T(), errors.As(err, &invalidConfigurationError), is.True())
+	then.AssertThat(s.T(), invalidConfigurationError.Field, is.EqualTo("Source.Sparte"))
+	then.AssertThat(s.T(), invalidConfigurationError.Rule, is.EqualTo("matches_sparte"))
+
+	configuration = inclusiveToMaKo()
+	configuration.Other = map[sparte.Sparte]mako_time_converter.DateTimeConversionConfiguration{
+		sparte.FERNWAERME: {Source: mako_time_converter.InclusiveDateOnlyGas(), Target: mako_time_converter.MaKoStandardGas()},
+	}
+	_, err = configuration.For(sparte.FERNWAERME)
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+}
+
+func (s *Suite) Test_SparteConfiguration_Rejects_Strom_And_Gas_In_Other() {
+	for _, excluded := range []sparte.Sparte{sparte.STROM, sparte.GAS} {
+		configuration := inclusiveToMaKo()
+		configuration.Other = map[sparte.Sparte]mako_time_converter.DateTimeConversionConfiguration{excluded: configuration.Strom}
+		// the entry is rejected for all Sparten, not only for the one that it would override
+		for _, requested := range []sparte.Sparte{sparte.STROM, sparte.GAS, sparte.WASSER} {
+			_, err := configuration.For(requested)
+			var invalidConfigurationError mako_time_converter.InvalidConfigurationError
+			then.AssertThat(s.T(), errors.As(err, &invalidConfigurationError), is.True())
+			then.AssertThat(s.T(), invalidConfigurationError.Field, is.EqualTo("Other["+excluded.String()+"]"))
+		}
+	}
+}
+
+func (s *Suite) Test_Validator_Rejects_Invalid_Sparte_Pairings() {
+	converter := getBerlinConverter()
+	now := time.Now()
+	valid := mako_time_converter.DateTimeConversionConfiguration{
+		Source: mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(true), Sparte: pointer(sparte.GAS)},
+		Target: mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(false)},
+	}
+	_, err := converter.Convert(now, valid)
+	then.AssertThat(s.T(), err, is.Nil())
+
+	stromWithGas := valid
+	stromWithGas.Source.Sparte = pointer(sparte.STROM)
+	unknownSparte := valid
+	unknownSparte.Target.Sparte = pointer(sparte.Sparte(17))
+	wasserWithoutGas := mako_time_converter.DateTimeConversionConfiguration{
+		Source: mako_time_converter.DateTimeConfiguration{Sparte: pointer(sparte.WASSER)},
+		Target: mako_time_converter.DateTimeConfiguration{Sparte: pointer(sparte.STROM)},
+	}
+	for _, invalid := range []mako_time_converter.DateTimeConversionConfiguration{stromWithGas, unknownSparte, wasserWithoutGas} {
+		_, err = converter.Convert(now, invalid)
+		then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+	}
+}
+
+func (s *Suite) Test_ParseDivisionCode() {
+	for code, expected := range map[string]sparte.Sparte{
+		"S2.1":       sparte.STROM,
+		"G1.0a":      sparte.GAS,
+		"STROM":      sparte.STROM,
+		"gas":        sparte.GAS,
+		"Fernwärme":  sparte.FERNWAERME,
+		"FERNWAERME": sparte.FERNWAERME,
+		" Wasser ":   sparte.WASSER,
+	} {
+		actual, err := sparte.ParseDivisionCode(code)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), actual, is.EqualTo(expected))
+	}
+	for _, code := range []string{"", "S", "X1.0", "Strom2"} {
+		_, err := sparte.ParseDivisionCode(code)
+		then.AssertThat(s.T(), errors.Is(err, sparte.ErrUnknownDivisionCode), is.True())
+	}
+}
@@ -0,0 +1,70 @@
+package mako_time_converter
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// ConvertedTime is a timestamp that is converted automatically when it is read from or written to a database column, so that the conversion cannot be forgotten in the business code.
+// It implements sql.Scanner and driver.Valuer; the Profile has to be set before scanning, e.g.:
+//
+//	end := ConversionProfile{Converter: converter, Configuration: configuration}.Time(time.Time{})
+//	err := row.Scan(&end)
+//
+// NULL is represented by the zero Time.
+type ConvertedTime struct {
+	// Time is the timestamp as it is understood by the application (DateTimeConversionConfiguration.Target of the Profile)
+	Time time.Time
+	// Profile describes the conversion between the database column and the application
+	Profile ConversionProfile
+}
+
+var _ sql.Scanner = (*ConvertedTime)(nil)
+var _ driver.Valuer = ConvertedTime{}
+
+// Scan reads the value of the column and converts it with the Profile (see sql.Scanner)
+func (ct *ConvertedTime) Scan(src any) error {
+	if ct.Profile.Converter == nil {
+		return ErrIncompleteProfile
+	}
+	var columnValue time.Time
+	isDate := false
+	ok := true
+	switch value := src.(type) {
+	case nil:
+		ct.Time = time.Time{}
+		return nil
+	case time.Time:
+		columnValue = value
+	case string:
+		columnValue, isDate, ok = parseExternalTime(value)
+	case []byte:
+		columnValue, isDate, ok = parseExternalTime(string(value))
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedDatabaseValue, src)
+	}
+	if !ok {
+		return fmt.Errorf("%w: cannot parse '%s' as timestamp", ErrUnsupportedDatabaseValue, src)
+	}
+	// drivers return the values of date-only columns as midnight of an arbitrary location
+	result, err := ct.Profile.decode(columnValue, isDate || ct.Profile.isDateOnly())
+	if err != nil {
+		return err
+	}
+	ct.Time = result
+	return nil
+}
+
+// Value converts the Time with the inverted configuration of the Profile (see driver.Valuer). Dates of date-only columns are returned as UTC midnight of the German local date, so that drivers store the correct date.
+func (ct ConvertedTime) Value() (driver.Value, error) {
+	if ct.Time.IsZero() {
+		return nil, nil
+	}
+	result, err := ct.Profile.encode(ct.Time)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,81 @@
+package mako_time_converter_test
+
+import (
+	"errors"
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/mako_time_converter"
+	"time"
+)
+
+func (s *Suite) Test_ConvertedTime_Date_Only_Column() {
+	// a legacy column with inclusive German dates, the application uses MaKo gas end dates
+	profile, err := mako_time_converter.NewConversionProfile(getBerlinConverter(), mako_time_converter.DateTimeConversionConfiguration{
+		Source: mako_time_converter.InclusiveDateOnlyGas(),
+		Target: mako_time_converter.MaKoStandardGas(),
+	})
+	then.AssertThat(s.T(), err, is.Nil())
+	for _, columnValue := range []any{time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC), "2023-01-31", []byte("2023-01-31")} {
+		convertedTime := profile.Time(time.Time{})
+		err = convertedTime.Scan(columnValue)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), convertedTime.Time, is.EqualTo(time.Date(2023, 2, 1, 5, 0, 0, 0, time.UTC)))
+	}
+	value, err := profile.Time(time.Date(2023, 2, 1, 5, 0, 0, 0, time.UTC)).Value()
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), value.(time.Time), is.EqualTo(time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)))
+}
+
+func (s *Suite) Test_ConvertedTime_Timestamp_Column() {
+	profile, err := mako_time_converter.NewConversionProfile(getBerlinConverter(), mako_time_converter.DateTimeConversionConfiguration{
+		Source: mako_time_converter.MaKoStandardGas(),
+		Target: mako_time_converter.LegacyMidnightGas(),
+	})
+	then.AssertThat(s.T(), err, is.Nil())
+	for _, columnValue := range []any{time.Date(2023, 2, 1, 6, 0, 0, 0, time.FixedZone("CET", 3600)), "2023-02-01 05:00:00+00", "2023-02-01T05:00:00Z", []byte("2023-02-01 05:00:00")} {
+		convertedTime := profile.Time(time.Time{})
+		err = convertedTime.Scan(columnValue)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), convertedTime.Time, is.EqualTo(time.Date(2023, 1, 31, 23, 0, 0, 0, time.UTC)))
+	}
+	value, err := profile.Time(time.Date(2023, 1, 31, 23, 0, 0, 0, time.UTC)).Value()
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), value.(time.Time), is.EqualTo(time.Date(2023, 2, 1, 5, 0, 0, 0, time.UTC)))
+}
+
+func (s *Suite) Test_ConvertedTime_Null() {
+	profile, err := mako_time_converter.NewConversionProfile(getBerlinConverter(), mako_time_converter.DateTimeConversionConfiguration{Source: mako_time_converter.MaKoStandardGas(), Target: mako_time_converter.LegacyMidnightGas()})
+	then.AssertThat(s.T(), err, is.Nil())
+	convertedTime := profile.Time(time.Now())
+	err = convertedTime.Scan(nil)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), convertedTime.Time.IsZero(), is.True())
+	value, err := convertedTime.Value()
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), value == nil, is.True())
+}
+
+func (s *Suite) Test_ConvertedTime_Errors() {
+	var convertedTime mako_time_converter.ConvertedTime
+	err := convertedTime.Scan("2023-01-31")
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrIncompleteProfile), is.True())
+	_, err = mako_time_converter.ConvertedTime{Time: time.Now()}.Value()
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrIncompleteProfile), is.True())
+	_, err = mako_time_converter.NewConversionProfile(nil, mako_time_converter.DateTimeConversionConfiguration{})
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrIncompleteProfile), is.True())
+	_, err = mako_time_converter.NewConversionProfile(getBerlinConverter(), mako_time_converter.DateTimeConversionConfiguration{Source: mako_time_converter.MaKoStandardGas(), Target: mako_time_converter.MaKoStandardStrom()})
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+
+	profile := mako_time_converter.ConversionProfile{Converter: getBerlinConverter(), Configuration: mako_time_converter.DateTimeConversionConfiguration{Source: mako_time_converter.MaKoStandardGas(), Target: mako_time_converter.LegacyMidnightGas()}}
+	convertedTime = profile.Time(time.Time{})
+	for _, unsupportedValue := range []any{int64(1675227600), "yesterday"} {
+		err = convertedTime.Scan(unsupportedValue)
+		then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrUnsupportedDatabaseValue), is.True())
+	}
+	profile.Configuration.Target = mako_time_converter.MaKoStandardStrom()
+	convertedTime = profile.Time(time.Now())
+	err = convertedTime.Scan("2023-01-31")
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+	_, err = convertedTime.Value()
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+}
@@ -0,0 +1,211 @@
+package mako_time_converter
+
+import (
+	"errors"
+	"fmt"
+	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// StructTagKey is the key of the struct tags that are evaluated by ConvertStruct, e.g. `makotime:"end,exclusive,gas"`
+const StructTagKey = "makotime"
+
+// ErrInvalidStructTag is returned if a makotime struct tag cannot be parsed or is used on a field that is not a time.Time
+var ErrInvalidStructTag = errors.New("invalid makotime struct tag")
+
+// ErrInvalidDirection is returned if ConvertStruct is called with a Direction that is neither ToMaKo nor FromMaKo (e.g. the zero value)
+var ErrInvalidDirection = errors.New("invalid direction")
+
+// ErrNotAStructPointer is returned if ConvertStruct is called with something else than a non-nil pointer to a struct
+var ErrNotAStructPointer = errors.New("not a pointer to a struct")
+
+// Direction describes in which direction ConvertStruct converts the fields of a struct
+type Direction int
+
+const (
+	// ToMaKo converts the fields from the semantics described by their struct tags to the MaKo standard (exclusive end dates, Gas-Tag aware gas dates, no stripped times)
+	ToMaKo Direction = iota + 1
+	// FromMaKo converts the fields from the MaKo standard to the semantics described by their struct tags
+	FromMaKo
+)
+
+// ParseStructTag returns the DateTimeConfiguration that is described by the value of a makotime struct tag.
+// The value is a comma separated list of:
+//   - "start" (default) or "end" for start or end dates
+//   - the kind of end dates: "inclusive", "exclusive" (default for end dates) or any other enddatetimekind.EndDateTimeKind in lower case (e.g. "inclusive_last_second")
+//   - "gas" for Gas-Tag aware gas dates, "gas_midnight" for gas dates that are not Gas-Tag aware or "strom" (default)
+//   - "date" if the time is stripped (StripTime)
+func ParseStructTag(tag string) (DateTimeConfiguration, error) {
+	var result DateTimeConfiguration
+	var kind *enddatetimekind.EndDateTimeKind
+	for _, token := range strings.Split(tag, ",") {
+		token = strings.TrimSpace(token)
+		var parsedKind enddatetimekind.EndDateTimeKind
+		switch {
+		case token == "":
+			continue
+		case token == "start":
+			result.IsEndDate = false
+		case token == "end":
+			result.IsEndDate = true
+		case token == "gas" || token == "gas_midnight":
+			isGasTagAware := token == "gas"
+			result.IsGas = true
+			result.IsGasTagAware = &isGasTagAware
+		case token == "strom":
+			result.IsGas = false
+			result.IsGasTagAware = nil
+		case token == "date":
+			result.StripTime = true
+		case parsedKind.UnmarshalJSON([]byte(`"`+strings.ToUpper(token)+`"`)) == nil:
+			kind = &parsedKind
+		default:
+			return DateTimeConfiguration{}, fmt.Errorf("%w: unknown token '%s' in '%s'", ErrInvalidStructTag, token, tag)
+		}
+	}
+	if kind != nil && !result.IsEndDate {
+		return DateTimeConfiguration{}, fmt.Errorf("%w: '%s' describes an end date kind but no end date", ErrInvalidStructTag, tag)
+	}
+	if result.IsEndDate {
+		if kind == nil {
+			exclusive := enddatetimekind.EXCLUSIVE
+			kind = &exclusive
+		}
+		result.EndDateTimeKind = kind
+	}
+	return result, nil
+}
+
+// makoStandard returns the configuration of MaKo timestamps with the same meaning (start/end date, Sparte) as the given configuration
+func makoStandard(configuration DateTimeConfiguration) DateTimeConfiguration {
+	result := DateTimeConfiguration{IsEndDate: configuration.IsEndDate, IsGas: configuration.IsGas}
+	if result.IsGas {
+		isGasTagAware := true
+		result.IsGasTagAware = &isGasTagAware
+	}
+	if result.IsEndDate {
+		exclusive := enddatetimekind.EXCLUSIVE
+		result.EndDateTimeKind = &exclusive
+	}
+	return result
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// ConvertStruct uses the converter to convert all time.Time fields (also pointers, slices and fields of nested structs) of the struct to which v points in place. The semantics of each field are described by its makotime struct tag (see ParseStructTag); untagged fields, zero times and nil pointers are not changed.
+// The struct is only changed if all fields can be converted. Pointers that have already been visited (e.g. back-references in a graph of structs) are not followed again, so every time.Time is converted at most once.
+// The direction tells whether the fields are converted to or from the MaKo standard. It returns ErrNotAStructPointer if v is not a non-nil pointer to a struct, ErrInvalidDirection if the direction is neither ToMaKo nor FromMaKo and an error that wraps ErrInvalidStructTag if a tag is invalid.
+func ConvertStruct(converter GasTagConverter, v any, direction Direction) error {
+	if direction != ToMaKo && direction != FromMaKo {
+		return fmt.Errorf("%w: %d", ErrInvalidDirection, direction)
+	}
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T", ErrNotAStructPointer, v)
+	}
+	conversion := structConversion{
+		converter: converter,
+		direction: direction,
+		visited:   map[visitedPointer]bool{},
+		results:   map[uintptr]convertedField{},
+	}
+	if err := conversion.convertValue(value.Elem(), nil, value.Elem().Type().Name()); err != nil {
+		return err
+	}
+	// all fields could be converted, so the results are written now
+	for _, result := range conversion.results {
+		result.field.Set(reflect.ValueOf(result.value))
+	}
+	return nil
+}
+
+// visitedPointer identifies a pointer that has already been followed. The type is part of the key because a pointer to a struct has the same address as a pointer to its first field.
+type visitedPointer struct {
+	address uintptr
+	typ     reflect.Type
+}
+
+// convertedField is the result of the conversion of a time.Time field which is written after all fields have been converted
+type convertedField struct {
+	field reflect.Value
+	value time.Time
+}
+
+// structConversion is the state of a single ConvertStruct call
+type structConversion struct {
+	converter GasTagConverter
+	direction Direction
+	// visited contains all pointers that have already been followed
+	visited map[visitedPointer]bool
+	// results contains the converted time.Time values by the address of the field
+	results map[uintptr]convertedField
+}
+
+// convertValue converts the given value. The configuration is nil as long as no makotime struct tag applies to the value.
+func (c *structConversion) convertValue(value reflect.Value, configuration *DateTimeConfiguration, path string) error {
+	switch {
+	case value.Type() == timeType:
+		if configuration == nil || value.Interface().(time.Time).IsZero() {
+			return nil
+		}
+		if _, converted := c.results[value.Addr().Pointer()]; converted {
+			return nil
+		}
+		conversion := DateTimeConversionConfiguration{Source: *configuration, Target: makoStandard(*configuration)}
+		if c.direction == FromMaKo {
+			conversion = conversion.Invert()
+		}
+		result, err := c.converter.Convert(value.Interface().(time.Time), conversion)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		c.results[value.Addr().Pointer()] = convertedField{field: value, value: result}
+		return nil
+	case value.Kind() == reflect.Pointer:
+		if value.IsNil() {
+			return nil
+		}
+		key := visitedPointer{address: value.Pointer(), typ: value.Type()}
+		if c.visited[key] {
+			return nil
+		}
+		c.visited[key] = true
+		return c.convertValue(value.Elem(), configuration, path)
+	case value.Kind() == reflect.Slice || value.Kind() == reflect.Array:
+		for index := 0; index < value.Len(); index++ {
+			if err := c.convertValue(value.Index(index), configuration, fmt.Sprintf("%s[%d]", path, index)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case value.Kind() == reflect.Struct:
+		if configuration != nil {
+			return fmt.Errorf("%w: %s is not a time.Time", ErrInvalidStructTag, path)
+		}
+		for index := 0; index < value.NumField(); index++ {
+			field := value.Type().Field(index)
+			tag, hasTag := field.Tag.Lookup(StructTagKey)
+			if !field.IsExported() || tag == "-" {
+				continue
+			}
+			var fieldConfiguration *DateTimeConfiguration
+			if hasTag {
+				parsed, err := ParseStructTag(tag)
+				if err != nil {
+					return fmt.Errorf("%s.%s: %w", path, field.Name, err)
+				}
+				fieldConfiguration = &parsed
+			}
+			if err := c.convertValue(value.Field(index), fieldConfiguration, path+"."+field.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if configuration != nil {
+		return fmt.Errorf("%w: %s is not a time.Time", ErrInvalidStructTag, path)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package mako_time_converter_test
+
+import (
+	"errors"
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/mako_time_converter"
+	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
+	"time"
+)
+
+type assignment struct {
+	Start time.Time `makotime:"start,gas_midnight"`
+	End   time.Time `makotime:"end,inclusive,gas_midnight,date"`
+}
+
+type contract struct {
+	Begin        time.Time   `makotime:"start,gas_midnight"`
+	End          *time.Time  `makotime:"end,inclusive,gas_midnight"`
+	Cancellation *time.Time  `makotime:"end,inclusive,gas_midnight"`
+	StromEnds    []time.Time `makotime:"end,inclusive"`
+	Assignments  []assignment
+	Nested       *assignment
+	Created      time.Time // not converted, because it has no tag
+	Ignored      time.Time `makotime:"-"`
+}
+
+func (s *Suite) Test_ParseStructTag() {
+	tags := map[string]mako_time_converter.DateTimeConfiguration{
+		"":                                {},
+		"start,gas":                       {IsGas: true, IsGasTagAware: pointer(true)},
+		"end,exclusive,gas":               mako_time_converter.MaKoStandardGas(),
+		"end":                             mako_time_converter.MaKoStandardStrom(),
+		"end,inclusive,gas_midnight,date": mako_time_converter.InclusiveDateOnlyGas(),
+		"end, inclusive_last_second":      {IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.INCLUSIVE_LAST_SECOND)},
+	}
+	for tag, expected := range tags {
+		actual, err := mako_time_converter.ParseStructTag(tag)
+		then.AssertThat(s.T(), err, is.Nil())
+		then.AssertThat(s.T(), actual, is.EqualTo(expected))
+	}
+	for _, invalidTag := range []string{"end,foo", "start,inclusive"} {
+		_, err := mako_time_converter.ParseStructTag(invalidTag)
+		then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidStructTag), is.True())
+	}
+}
+
+func (s *Suite) Test_ConvertStruct() {
+	converter := getBerlinConverter()
+	created := time.Date(2023, 1, 15, 12, 0, 0, 0, time.UTC)
+	value := contract{
+		Begin:       time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC),         // 2023-01-01 German midnight
+		End:         pointer(time.Date(2023, 1, 30, 23, 0, 0, 0, time.UTC)), // 2023-01-31 inclusive
+		StromEnds:   []time.Time{time.Date(2023, 1, 30, 23, 0, 0, 0, time.UTC)},
+		Assignments: []assignment{{Start: time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC), End: time.Date(2023, 1, 31, 10, 0, 0, 0, time.UTC)}},
+		Nested:      &assignment{Start: time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC)},
+		Created:     created,
+		Ignored:     created,
+	}
+	err := mako_time_converter.ConvertStruct(converter, &value, mako_time_converter.ToMaKo)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), value.Begin, is.EqualTo(time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), *value.End, is.EqualTo(time.Date(2023, 2, 1, 5, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), value.Cancellation == nil, is.True())
+	then.AssertThat(s.T(), value.StromEnds[0], is.EqualTo(time.Date(2023, 1, 31, 23, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), value.Assignments[0].Start, is.EqualTo(time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), value.Assignments[0].End, is.EqualTo(time.Date(2023, 2, 1, 5, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), value.Nested.Start, is.EqualTo(time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), value.Nested.End.IsZero(), is.True())
+	then.AssertThat(s.T(), value.Created, is.EqualTo(created))
+	then.AssertThat(s.T(), value.Ignored, is.EqualTo(created))
+
+	err = mako_time_converter.ConvertStruct(converter, &value, mako_time_converter.FromMaKo)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), value.Begin, is.EqualTo(time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), *value.End, is.EqualTo(time.Date(2023, 1, 30, 23, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), value.Assignments[0].End, is.EqualTo(time.Date(2023, 1, 30, 23, 0, 0, 0, time.UTC)))
+}
+
+func (s *Suite) Test_ConvertStruct_Errors() {
+	converter := getBerlinConverter()
+	for _, invalidArgument := range []any{nil, contract{}, (*contract)(nil), pointer(42)} {
+		err := mako_time_converter.ConvertStruct(converter, invalidArgument, mako_time_converter.ToMaKo)
+		then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrNotAStructPointer), is.True())
+	}
+	type invalidTag struct {
+		End time.Time `makotime:"end,foo"`
+	}
+	err := mako_time_converter.ConvertStruct(converter, &invalidTag{}, mako_time_converter.ToMaKo)
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidStructTag), is.True())
+	then.AssertThat(s.T(), err.Error(), is.StringContaining("invalidTag.End"))
+	type tagOnNonTime struct {
+		Name string `makotime:"end"`
+	}
+	err = mako_time_converter.ConvertStruct(converter, &tagOnNonTime{Name: "foo"}, mako_time_converter.ToMaKo)
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidStructTag), is.True())
+	type invalidConfiguration struct {
+		End time.Time `makotime:"end,inclusive_last_second,date"`
+	}
+	err = mako_time_converter.ConvertStruct(converter, &invalidConfiguration{End: time.Now()}, mako_time_converter.ToMaKo)
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+}
+
+// node is a contract with back-references, e.g. a parent/child contract graph
+type node struct {
+	Begin    time.Time `makotime:"start,gas_midnight"`
+	Parent   *node
+	Children []*node
+}
+
+func (s *Suite) Test_ConvertStruct_With_Cycles() {
+	parent := &node{Begin: time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC)}
+	child := &node{Begin: time.Date(2023, 1, 31, 23, 0, 0, 0, time.UTC), Parent: parent}
+	parent.Children = []*node{child, child}
+	parent.Parent = parent // self-reference
+	err := mako_time_converter.ConvertStruct(getBerlinConverter(), parent, mako_time_converter.ToMaKo)
+	then.AssertThat(s.T(), err, is.Nil())
+	// every time is converted exactly once, although it is reachable on several paths
+	then.AssertThat(s.T(), parent.Begin, is.EqualTo(time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), child.Begin, is.EqualTo(time.Date(2023, 2, 1, 5, 0, 0, 0, time.UTC)))
+}
+
+func (s *Suite) Test_ConvertStruct_Is_Atomic() {
+	type partiallyInvalid struct {
+		Begin time.Time `makotime:"start,gas_midnight"`
+		End   time.Time `makotime:"end,inclusive_last_second,date"`
+	}
+	begin := time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC)
+	value := partiallyInvalid{Begin: begin, End: time.Now()}
+	err := mako_time_converter.ConvertStruct(getBerlinConverter(), &value, mako_time_converter.ToMaKo)
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+	then.AssertThat(s.T(), value.Begin, is.EqualTo(begin)) // not converted, because End failed
+}
+
+func (s *Suite) Test_ConvertStruct_Rejects_Invalid_Direction() {
+	value := assignment{Start: time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC)}
+	for _, invalidDirection := range []mako_time_converter.Direction{0, 3} {
+		err := mako_time_converter.ConvertStruct(getBerlinConverter(), &value, invalidDirection)
+		then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidDirection), is.True())
+	}
+	then.AssertThat(s.T(), value.Start, is.EqualTo(time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,91 @@
+package mako_time_converter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Names of the steps of a conversion in the order in which they are executed
+const (
+	// StepStripSource removes the time of day before the conversion (DateTimeConversionConfiguration.Source.StripTime)
+	StepStripSource = "strip source time"
+	// StepToExclusive converts the source end date to an exclusive end date (e.g. adds a German day to an inclusive end date)
+	StepToExclusive = "source end date to exclusive"
+	// StepGasTagShift converts between German 6am and German midnight
+	StepGasTagShift = "gas day shift"
+	// StepFromExclusive converts the exclusive end date to the kind of the target end date (e.g. subtracts a German day for an inclusive end date)
+	StepFromExclusive = "exclusive end date to target"
+	// StepStripTarget removes the time of day after the conversion (DateTimeConversionConfiguration.Target.StripTime)
+	StepStripTarget = "strip target time"
+)
+
+// TraceStep is a single step of a conversion
+type TraceStep struct {
+	// Name is one of StepStripSource, StepToExclusive, StepGasTagShift, StepFromExclusive, StepStripTarget
+	Name string `json:"name"`
+	// Applied is true if the step modified (or might have modified) the timestamp; false if it has been skipped
+	Applied bool `json:"applied"`
+	// Reason explains why the step has been applied or skipped, e.g. "not German 6am, gas day shift skipped"
+	Reason string `json:"reason"`
+	// UTC is the timestamp after the step
+	UTC time.Time `json:"utc"`
+	// Local is the timestamp after the step in German local time
+	Local time.Time `json:"local"`
+}
+
+// ConversionTrace explains a conversion: it contains all steps (applied and skipped) in the order in which they are executed
+type ConversionTrace struct {
+	Configuration DateTimeConversionConfiguration `json:"configuration"`
+	Input         time.Time                       `json:"input"`
+	Output        time.Time                       `json:"output"`
+	Steps         []TraceStep                     `json:"steps"`
+	location      *time.Location
+}
+
+// record appends a step to the trace. It does nothing if the trace is nil, so that conversions without trace don't allocate.
+func (t *ConversionTrace) record(name string, applied bool, reason string, timestamp time.Time) {
+	if t == nil {
+		return
+	}
+	t.Steps = append(t.Steps, TraceStep{Name: name, Applied: applied, Reason: reason, UTC: timestamp.UTC(), Local: timestamp.In(t.location)})
+}
+
+// String returns a human readable multi line representation of the trace, e.g. for logs
+func (t ConversionTrace) String() string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s -> %s\n", t.Input.UTC().Format(time.RFC3339Nano), t.Output.UTC().Format(time.RFC3339Nano)))
+	for index, step := range t.Steps {
+		status := "skipped"
+		if step.Applied {
+			status = "applied"
+		}
+		builder.WriteString(fmt.Sprintf("%d. %s %s: %s => %s (%s)\n", index+1, step.Name, status, step.Reason, step.UTC.Format(time.RFC3339Nano), step.Local.Format(time.RFC3339Nano)))
+	}
+	return builder.String()
+}
+
+// ApplyWithTrace converts the timestamp like Apply but additionally returns the ConversionTrace
+func (p ConversionPlan) ApplyWithTrace(timestamp time.Time) (ConversionTrace, error) {
+	trace := ConversionTrace{
+		Configuration: p.configuration,
+		Input:         timestamp,
+		Steps:         make([]TraceStep, 0, 5),
+		location:      p.converter.location,
+	}
+	result, err := p.apply(timestamp, &trace)
+	if err != nil {
+		return ConversionTrace{}, err
+	}
+	trace.Output = result
+	return trace, nil
+}
+
+// ConvertWithTrace converts the timestamp like GasTagConverter.Convert but additionally returns a ConversionTrace that explains which steps of the conversion have been applied or skipped (and why)
+func ConvertWithTrace(converter GasTagConverter, timestamp time.Time, configuration DateTimeConversionConfiguration) (ConversionTrace, error) {
+	plan, err := locationBased(converter).Compile(configuration)
+	if err != nil {
+		return ConversionTrace{}, err
+	}
+	return plan.ApplyWithTrace(timestamp)
+}
@@ -0,0 +1,69 @@
+package mako_time_converter_test
+
+import (
+	"errors"
+	"github.com/corbym/gocrest/is"
+	"github.com/corbym/gocrest/then"
+	"github.com/hochfrequenz/mako_time_converter"
+	"github.com/hochfrequenz/mako_time_converter/enddatetimekind"
+	"time"
+)
+
+func (s *Suite) Test_ConvertWithTrace() {
+	converter := getBerlinConverter()
+	configuration := mako_time_converter.DateTimeConversionConfiguration{
+		Source: mako_time_converter.MaKoStandardGas(),
+		Target: mako_time_converter.InclusiveDateOnlyGas(),
+	}
+	input := time.Date(2023, 2, 1, 5, 0, 0, 0, time.UTC)
+	trace, err := mako_time_converter.ConvertWithTrace(converter, input, configuration)
+	then.AssertThat(s.T(), err, is.Nil())
+	expectedOutput, err := converter.Convert(input, configuration)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), trace.Input, is.EqualTo(input))
+	then.AssertThat(s.T(), trace.Output, is.EqualTo(expectedOutput))
+	then.AssertThat(s.T(), trace.Configuration, is.EqualTo(configuration))
+	then.AssertThat(s.T(), len(trace.Steps), is.EqualTo(5))
+
+	names := make([]string, 0, len(trace.Steps))
+	applied := make([]bool, 0, len(trace.Steps))
+	for _, step := range trace.Steps {
+		names = append(names, step.Name)
+		applied = append(applied, step.Applied)
+	}
+	then.AssertThat(s.T(), names, is.EqualTo([]string{mako_time_converter.StepStripSource, mako_time_converter.StepToExclusive, mako_time_converter.StepGasTagShift, mako_time_converter.StepFromExclusive, mako_time_converter.StepStripTarget}))
+	then.AssertThat(s.T(), applied, is.EqualTo([]bool{false, false, true, true, true}))
+	then.AssertThat(s.T(), trace.Steps[2].UTC, is.EqualTo(time.Date(2023, 1, 31, 23, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), trace.Steps[2].Local.Format(time.RFC3339), is.EqualTo("2023-02-01T00:00:00+01:00"))
+	then.AssertThat(s.T(), trace.Steps[3].UTC, is.EqualTo(time.Date(2023, 1, 30, 23, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), trace.String(), is.StringContaining("3. gas day shift applied: German 6am converted to German midnight => 2023-01-31T23:00:00Z (2023-02-01T00:00:00+01:00)"))
+}
+
+func (s *Suite) Test_ConvertWithTrace_Skipped_Steps() {
+	converter := getBerlinConverter()
+	configuration := mako_time_converter.DateTimeConversionConfiguration{
+		Source: mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(true)},
+		Target: mako_time_converter.DateTimeConfiguration{IsGas: true, IsGasTagAware: pointer(false)},
+	}
+	trace, err := mako_time_converter.ConvertWithTrace(converter, time.Date(2023, 2, 1, 7, 0, 0, 0, time.UTC), configuration)
+	then.AssertThat(s.T(), err, is.Nil())
+	then.AssertThat(s.T(), trace.Output, is.EqualTo(time.Date(2023, 2, 1, 7, 0, 0, 0, time.UTC)))
+	then.AssertThat(s.T(), trace.Steps[2].Applied, is.False())
+	then.AssertThat(s.T(), trace.Steps[2].Reason, is.EqualTo("not German 6am, gas day shift skipped"))
+
+	identity := mako_time_converter.DateTimeConversionConfiguration{Source: mako_time_converter.MaKoStandardGas(), Target: mako_time_converter.MaKoStandardGas()}
+	trace, err = mako_time_converter.ConvertWithTrace(converter, time.Date(2023, 2, 1, 5, 0, 0, 0, time.UTC), identity)
+	then.AssertThat(s.T(), err, is.Nil())
+	for _, step := range trace.Steps {
+		then.AssertThat(s.T(), step.Applied, is.False())
+	}
+	then.AssertThat(s.T(), trace.Steps[4].Reason, is.EqualTo("source and target are the same"))
+}
+
+func (s *Suite) Test_ConvertWithTrace_Invalid_Configuration() {
+	_, err := mako_time_converter.ConvertWithTrace(getBerlinConverter(), time.Now(), mako_time_converter.DateTimeConversionConfiguration{
+		Source: mako_time_converter.DateTimeConfiguration{IsEndDate: true},
+		Target: mako_time_converter.DateTimeConfiguration{IsEndDate: true, EndDateTimeKind: pointer(enddatetimekind.EXCLUSIVE)},
+	})
+	then.AssertThat(s.T(), errors.Is(err, mako_time_converter.ErrInvalidConfiguration), is.True())
+}